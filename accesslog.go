@@ -0,0 +1,186 @@
+package zwrap
+
+import (
+	"bufio"
+	"io"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+// DefaultRequestIDHeader is the header NewAccessLog reads request IDs from unless told otherwise.
+const DefaultRequestIDHeader = "X-Request-Id"
+
+// AccessLogOptions configure the middleware returned by NewAccessLog.
+type AccessLogOptions struct {
+	// Level returns the level a response with the given status is logged at. If nil, DefaultAccessLogLevel is used.
+	Level func(status int) zerolog.Level
+	// ExcludePaths lists request paths that are not logged, such as health checks. Their handlers still get
+	// a request logger.
+	ExcludePaths []string
+	// RequestIDHeader is the header holding the request ID, DefaultRequestIDHeader if empty.
+	RequestIDHeader string
+	// NewRequestID, if set, generates an ID for requests that arrive without one. The generated ID is also
+	// set on the response header.
+	NewRequestID func() string
+}
+
+// DefaultAccessLogLevel logs 5xx responses at error level, 4xx responses at warn level and everything else at info level.
+func DefaultAccessLogLevel(status int) zerolog.Level {
+	switch {
+	case status >= 500:
+		return zerolog.ErrorLevel
+	case status >= 400:
+		return zerolog.WarnLevel
+	default:
+		return zerolog.InfoLevel
+	}
+}
+
+// NewAccessLog returns middleware logging one line per request through l, with the method, path, status, bytes,
+// duration, remote_addr, user_agent and request_id fields. Handlers can get a Child of l carrying the request_id
+// field with FromContext(r.Context()). Requests whose handler panics are logged with status 500 before the panic
+// carries on up the stack.
+func NewAccessLog(l *Logger, opts AccessLogOptions) func(http.Handler) http.Handler {
+	if opts.Level == nil {
+		opts.Level = DefaultAccessLogLevel
+	}
+	if opts.RequestIDHeader == "" {
+		opts.RequestIDHeader = DefaultRequestIDHeader
+	}
+	excluded := make(map[string]struct{}, len(opts.ExcludePaths))
+	for _, p := range opts.ExcludePaths {
+		excluded[p] = struct{}{}
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			start := time.Now()
+
+			id := r.Header.Get(opts.RequestIDHeader)
+			if id == "" && opts.NewRequestID != nil {
+				id = opts.NewRequestID()
+				w.Header().Set(opts.RequestIDHeader, id)
+			}
+			rl := l.Child()
+			if id != "" {
+				rl.update(func(c *config) {
+					c.base = c.base.With().Str("request_id", id).Logger()
+				})
+			}
+			r = r.WithContext(NewContext(r.Context(), rl))
+
+			sw := &statusWriter{ResponseWriter: w}
+			panicked := true
+			defer func() {
+				if _, ok := excluded[r.URL.Path]; ok {
+					return
+				}
+				if panicked {
+					sw.status = http.StatusInternalServerError
+				} else if sw.status == 0 {
+					sw.status = http.StatusOK
+				}
+				c := rl.config()
+				e := c.event(opts.Level(sw.status)).Ctx(r.Context())
+				if e == nil {
+					return
+				}
+				e.Str("method", r.Method).
+					Str("path", r.URL.Path).
+					Int("status", sw.status).
+					Int64("bytes", sw.bytes).
+					Dur("duration", time.Since(start)).
+					Str("remote_addr", r.RemoteAddr).
+					Str("user_agent", r.UserAgent()).
+					Msg("request")
+			}()
+			next.ServeHTTP(sw, r)
+			panicked = false
+		})
+	}
+}
+
+var (
+	_ http.Flusher  = (*statusWriter)(nil)
+	_ http.Hijacker = (*statusWriter)(nil)
+	_ http.Pusher   = (*statusWriter)(nil)
+	_ io.ReaderFrom = (*statusWriter)(nil)
+)
+
+// statusWriter records the status and size of a response.
+type statusWriter struct {
+	http.ResponseWriter
+	status int
+	bytes  int64
+}
+
+func (w *statusWriter) WriteHeader(status int) {
+	if w.status == 0 {
+		w.status = status
+	}
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *statusWriter) Write(p []byte) (int, error) {
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+	n, err := w.ResponseWriter.Write(p)
+	w.bytes += int64(n)
+	return n, err
+}
+
+func (w *statusWriter) Flush() {
+	if f, ok := w.ResponseWriter.(http.Flusher); ok {
+		if w.status == 0 {
+			w.status = http.StatusOK
+		}
+		f.Flush()
+	}
+}
+
+// Hijack hands the connection over to the caller, as websocket upgrades do. A hijacked response is logged
+// with status 101 unless the handler set a status first.
+func (w *statusWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	h, ok := w.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, http.ErrNotSupported
+	}
+	conn, rw, err := h.Hijack()
+	if err == nil && w.status == 0 {
+		w.status = http.StatusSwitchingProtocols
+	}
+	return conn, rw, err
+}
+
+// ReadFrom keeps the underlying ResponseWriter's io.ReaderFrom, which http.ServeContent and io.Copy use
+// to send files with sendfile.
+func (w *statusWriter) ReadFrom(r io.Reader) (int64, error) {
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+	var n int64
+	var err error
+	if rf, ok := w.ResponseWriter.(io.ReaderFrom); ok {
+		n, err = rf.ReadFrom(r)
+	} else {
+		n, err = io.Copy(w.ResponseWriter, r)
+	}
+	w.bytes += n
+	return n, err
+}
+
+func (w *statusWriter) Push(target string, opts *http.PushOptions) error {
+	if p, ok := w.ResponseWriter.(http.Pusher); ok {
+		return p.Push(target, opts)
+	}
+	return http.ErrNotSupported
+}
+
+// Unwrap lets http.ResponseController reach the underlying ResponseWriter.
+func (w *statusWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
@@ -0,0 +1,159 @@
+package zwrap
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/rs/zerolog"
+)
+
+func TestDefaultAccessLogLevel(t *testing.T) {
+	tests := []struct {
+		status int
+		want   zerolog.Level
+	}{
+		{200, zerolog.InfoLevel},
+		{304, zerolog.InfoLevel},
+		{404, zerolog.WarnLevel},
+		{503, zerolog.ErrorLevel},
+	}
+	for _, tt := range tests {
+		if got := DefaultAccessLogLevel(tt.status); got != tt.want {
+			t.Errorf("DefaultAccessLogLevel(%d) = %v, want %v", tt.status, got, tt.want)
+		}
+	}
+}
+
+func TestNewAccessLog(t *testing.T) {
+	buf := new(bytes.Buffer)
+	zl := Wrap(zerolog.New(buf))
+	mw := NewAccessLog(zl, AccessLogOptions{
+		ExcludePaths: []string{"/healthz"},
+		NewRequestID: func() string { return "generated" },
+	})
+	h := mw(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		FromContext(r.Context()).Info("handling")
+		switch r.URL.Path {
+		case "/missing":
+			http.NotFound(w, r)
+		case "/broken":
+			w.WriteHeader(http.StatusBadGateway)
+		default:
+			_, _ = w.Write([]byte("hello"))
+		}
+	}))
+
+	serve := func(path, id string) *httptest.ResponseRecorder {
+		r := httptest.NewRequest(http.MethodGet, path, nil)
+		r.Header.Set("User-Agent", "test-agent")
+		if id != "" {
+			r.Header.Set(DefaultRequestIDHeader, id)
+		}
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, r)
+		return w
+	}
+	serve("/ok", "abc")
+	serve("/missing", "")
+	serve("/broken", "def")
+	serve("/healthz", "ghi")
+	if got := serve("/ok", "").Header().Get(DefaultRequestIDHeader); got != "generated" {
+		t.Errorf("expected generated request ID on the response, got %q", got)
+	}
+
+	ms := decodeLines(t, buf)
+	want := []struct {
+		level, msg, path, id string
+		status               float64
+	}{
+		{"info", "handling", "", "abc", 0},
+		{"info", "request", "/ok", "abc", 200},
+		{"info", "handling", "", "generated", 0},
+		{"warn", "request", "/missing", "generated", 404},
+		{"info", "handling", "", "def", 0},
+		{"error", "request", "/broken", "def", 502},
+		{"info", "handling", "", "ghi", 0},
+		{"info", "handling", "", "generated", 0},
+		{"info", "request", "/ok", "generated", 200},
+	}
+	if len(ms) != len(want) {
+		t.Fatalf("expected %d lines, got %d: %s", len(want), len(ms), buf.String())
+	}
+	for i, w := range want {
+		m := ms[i]
+		if m["level"] != w.level || m["message"] != w.msg || m["request_id"] != w.id {
+			t.Errorf("line %d: unexpected record: %v", i, m)
+		}
+		if w.msg != "request" {
+			continue
+		}
+		if m["path"] != w.path || m["status"] != w.status || m["method"] != "GET" ||
+			m["user_agent"] != "test-agent" || m["remote_addr"] == nil || m["duration"] == nil {
+			t.Errorf("line %d: unexpected record: %v", i, m)
+		}
+	}
+	if ms[1]["bytes"] != float64(len("hello")) {
+		t.Errorf("expected bytes to be %d, got %v", len("hello"), ms[1]["bytes"])
+	}
+}
+
+func TestNewAccessLog_Panic(t *testing.T) {
+	buf := new(bytes.Buffer)
+	h := NewAccessLog(Wrap(zerolog.New(buf)), AccessLogOptions{})(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+		panic("yeet")
+	}))
+
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Error("expected the panic to propagate")
+			}
+		}()
+		h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/boom", nil))
+	}()
+
+	ms := decodeLines(t, buf)
+	if len(ms) != 1 || ms[0]["status"] != float64(http.StatusInternalServerError) || ms[0]["level"] != "error" {
+		t.Errorf("expected a 500 access log line for a panicking handler, got %s", buf.String())
+	}
+}
+
+func TestNewAccessLog_Hijack(t *testing.T) {
+	buf := new(bytes.Buffer)
+	logged := make(chan struct{})
+	mw := NewAccessLog(Wrap(zerolog.New(buf)), AccessLogOptions{})
+	h := mw(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hj, ok := w.(http.Hijacker)
+		if !ok {
+			t.Error("expected the ResponseWriter to implement http.Hijacker")
+			return
+		}
+		conn, rw, err := hj.Hijack()
+		if err != nil {
+			t.Errorf("unexpected hijack error: %v", err)
+			return
+		}
+		defer conn.Close()
+		_, _ = rw.WriteString("HTTP/1.1 101 Switching Protocols\r\nConnection: close\r\n\r\n")
+		_ = rw.Flush()
+	}))
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		defer close(logged)
+		h.ServeHTTP(w, r)
+	}))
+	defer srv.Close()
+
+	resp, err := http.Get(srv.URL)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	_ = resp.Body.Close()
+	<-logged
+
+	ms := decodeLines(t, buf)
+	if len(ms) != 1 || ms[0]["status"] != float64(http.StatusSwitchingProtocols) {
+		t.Errorf("expected a 101 access log line for a hijacked connection, got %s", buf.String())
+	}
+}
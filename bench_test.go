@@ -0,0 +1,106 @@
+package zwrap
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strconv"
+	"sync/atomic"
+	"testing"
+
+	"github.com/rs/zerolog"
+)
+
+func BenchmarkLogger_Parallel(b *testing.B) {
+	zl := Wrap(zerolog.New(io.Discard)).WithPrefix("bench")
+	b.ReportAllocs()
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			zl.Info("yeet")
+		}
+	})
+}
+
+func BenchmarkLogger_ParallelDisabled(b *testing.B) {
+	zl := Wrap(zerolog.New(io.Discard).Level(zerolog.InfoLevel))
+	b.ReportAllocs()
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			zl.Debug("yeet")
+		}
+	})
+}
+
+// BenchmarkLogger_ParallelReconfigure logs from every goroutine while one of them keeps changing the prefix.
+func BenchmarkLogger_ParallelReconfigure(b *testing.B) {
+	zl := Wrap(zerolog.New(io.Discard))
+	var writer int32
+	b.ReportAllocs()
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		if atomic.CompareAndSwapInt32(&writer, 0, 1) {
+			for i := 0; pb.Next(); i++ {
+				zl.SetPrefix(strconv.Itoa(i % 8))
+			}
+			return
+		}
+		for pb.Next() {
+			zl.Info("yeet")
+		}
+	})
+}
+
+func BenchmarkLogger_Methods(b *testing.B) {
+	zl := Wrap(zerolog.New(io.Discard).Level(zerolog.DebugLevel)).WithNoPanics().WithNoFatals()
+	err := errors.New("yeet")
+	ctx := context.Background()
+	methods := []struct {
+		name string
+		fn   func()
+	}{
+		{"Print", func() { zl.Print("yeet", 1) }},
+		{"Printf", func() { zl.Printf("yeet %d", 1) }},
+		{"Println", func() { zl.Println("yeet", 1) }},
+		{"Logf", func() { zl.Logf("yeet %d", 1) }},
+		{"Error", func() { zl.Error("yeet", err) }},
+		{"Errorf", func() { zl.Errorf("yeet %v", err) }},
+		{"Errorln", func() { zl.Errorln("yeet", err) }},
+		{"Warn", func() { zl.Warn("yeet", 1) }},
+		{"Warnf", func() { zl.Warnf("yeet %d", 1) }},
+		{"Warnln", func() { zl.Warnln("yeet", 1) }},
+		{"Warning", func() { zl.Warning("yeet", 1) }},
+		{"Warningf", func() { zl.Warningf("yeet %d", 1) }},
+		{"Warningln", func() { zl.Warningln("yeet", 1) }},
+		{"Info", func() { zl.Info("yeet") }},
+		{"Infof", func() { zl.Infof("yeet %s", "yeet") }},
+		{"Infoln", func() { zl.Infoln("yeet", true) }},
+		{"InfoContext", func() { zl.InfoContext(ctx, "yeet") }},
+		{"Noticef", func() { zl.Noticef("yeet %d", 1) }},
+		{"Debug", func() { zl.Debug("yeet", 1.5) }},
+		{"Debugf", func() { zl.Debugf("yeet %f", 1.5) }},
+		{"Debugln", func() { zl.Debugln("yeet", 1.5) }},
+		{"Trace disabled", func() { zl.Trace("yeet", err) }},
+		{"Tracef disabled", func() { zl.Tracef("yeet %v", err) }},
+		{"Traceln disabled", func() { zl.Traceln("yeet", err) }},
+		{"Verbosef disabled", func() { zl.Verbosef("yeet %v", err) }},
+		{"Fatal bypassed", func() { zl.Fatal("yeet") }},
+		{"Fatalf bypassed", func() { zl.Fatalf("yeet %d", 1) }},
+		{"Fatalln bypassed", func() { zl.Fatalln("yeet") }},
+		{"Panic bypassed", func() { zl.Panic("yeet") }},
+		{"Panicf bypassed", func() { zl.Panicf("yeet %d", 1) }},
+		{"Panicln bypassed", func() { zl.Panicln("yeet") }},
+		{"InfoDepth", func() { zl.InfoDepth(0, "yeet") }},
+		{"Output", func() { _ = zl.Output(2, "yeet") }},
+		{"Write", func() { _, _ = zl.Write([]byte("yeet\n")) }},
+	}
+	for _, m := range methods {
+		b.Run(m.name, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				m.fn()
+			}
+		})
+	}
+}
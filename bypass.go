@@ -1,7 +1,7 @@
 package zwrap
 
-func (l *Logger) checkPanicBypass(fmt string, v ...interface{}) (string, []interface{}, bool) {
-	if !l.noPanic {
+func (c *config) checkPanicBypass(fmt string, v ...interface{}) (string, []interface{}, bool) {
+	if !c.noPanic {
 		return fmt, v, true
 	}
 
@@ -10,6 +10,13 @@ func (l *Logger) checkPanicBypass(fmt string, v ...interface{}) (string, []inter
 		return fmt, v, false
 	}
 
+	if msg, ok := c.kvMessage(v); ok {
+		nv := make([]interface{}, len(v))
+		copy(nv, v)
+		nv[0] = "[PANIC BYPASSED] " + msg
+		return fmt, nv, false
+	}
+
 	nv := make([]interface{}, len(v)+1)
 	nv[0] = "[PANIC BYPASSED]"
 	copy(nv[1:], v)
@@ -17,8 +24,8 @@ func (l *Logger) checkPanicBypass(fmt string, v ...interface{}) (string, []inter
 	return fmt, nv, false
 }
 
-func (l *Logger) checkFatalBypass(fmt string, v ...interface{}) (string, []interface{}, bool) {
-	if !l.noFatal {
+func (c *config) checkFatalBypass(fmt string, v ...interface{}) (string, []interface{}, bool) {
+	if !c.noFatal {
 		return fmt, v, true
 	}
 
@@ -27,6 +34,13 @@ func (l *Logger) checkFatalBypass(fmt string, v ...interface{}) (string, []inter
 		return fmt, v, false
 	}
 
+	if msg, ok := c.kvMessage(v); ok {
+		nv := make([]interface{}, len(v))
+		copy(nv, v)
+		nv[0] = "[FATAL BYPASSED] " + msg
+		return fmt, nv, false
+	}
+
 	nv := make([]interface{}, len(v)+1)
 	nv[0] = "[FATAL BYPASSED]"
 	copy(nv[1:], v)
@@ -35,38 +49,40 @@ func (l *Logger) checkFatalBypass(fmt string, v ...interface{}) (string, []inter
 }
 
 func (l *Logger) NoPanics(b bool) {
-	l.mu.Lock()
-	l.noPanic = b
-	l.mu.Unlock()
+	l.update(func(c *config) {
+		c.noPanic = b
+	})
 }
 
 func (l *Logger) NoFatals(b bool) {
-	l.mu.Lock()
-	l.noFatal = b
-	l.mu.Unlock()
+	l.update(func(c *config) {
+		c.noFatal = b
+	})
 }
 
 func (l *Logger) WithNoPanics() *Logger {
+	l = l.withTarget()
 	l.NoPanics(true)
 	return l
 }
 
 func (l *Logger) WithNoFatals() *Logger {
+	l = l.withTarget()
 	l.NoFatals(true)
 	return l
 }
 
 func (l *Logger) ForceLevel(level any) {
-	l.mu.Lock()
 	nl := castToZlogLevel(level)
-	l.forceLevel = &nl
-	l.printLevel = nl
-	nll := l.Logger.Level(nl)
-	l.Logger = &nll
-	l.mu.Unlock()
+	l.update(func(c *config) {
+		c.forceLevel = &nl
+		c.printLevel = nl
+		c.base = c.base.Level(nl)
+	})
 }
 
 func (l *Logger) WithForceLevel(level any) *Logger {
+	l = l.withTarget()
 	l.ForceLevel(level)
 	return l
 }
@@ -0,0 +1,81 @@
+package zwrap
+
+import (
+	"reflect"
+	"runtime"
+	"strings"
+
+	"github.com/rs/zerolog"
+)
+
+// CallerFileFieldName is the field the file:line of the call site is written to. It is kept separate from
+// zerolog.CallerFieldName, which the wrapper uses for its prefix.
+var CallerFileFieldName = "caller_file"
+
+var pkgPath = reflect.TypeOf(Logger{}).PkgPath()
+
+// ReportCaller controls whether every log line carries the file:line of the code that called the Logger.
+// zwrap's own frames, and those of the log and log/slog packages, are skipped automatically.
+func (l *Logger) ReportCaller(b bool) {
+	l.update(func(c *config) {
+		c.reportCaller = b
+	})
+}
+
+func (l *Logger) WithReportCaller() *Logger {
+	l = l.withTarget()
+	l.ReportCaller(true)
+	return l
+}
+
+// isWrapperFrame reports whether f belongs to zwrap or to a logging package calling into it.
+func isWrapperFrame(f runtime.Frame) bool {
+	switch {
+	case strings.HasPrefix(f.Function, pkgPath+"."):
+		// our own tests are callers like any other.
+		return !strings.HasSuffix(f.File, "_test.go")
+	case strings.HasPrefix(f.Function, "log."), strings.HasPrefix(f.Function, "log/slog."):
+		return true
+	default:
+		return false
+	}
+}
+
+// withAutoCaller attaches the file:line of the first frame outside of zwrap if caller reporting is enabled.
+func (c *config) withAutoCaller(e *zerolog.Event) *zerolog.Event {
+	if e == nil || !c.reportCaller {
+		return e
+	}
+	var pcs [32]uintptr
+	frames := runtime.CallersFrames(pcs[:runtime.Callers(2, pcs[:])])
+	for {
+		f, more := frames.Next()
+		if !isWrapperFrame(f) {
+			return e.Str(CallerFileFieldName, zerolog.CallerMarshalFunc(f.PC, f.File, f.Line))
+		}
+		if !more {
+			return e
+		}
+	}
+}
+
+// withCaller attaches the file:line of the frame skip frames above its caller.
+func (c *config) withCaller(e *zerolog.Event, skip int) *zerolog.Event {
+	if e == nil {
+		return e
+	}
+	pc, file, line, ok := runtime.Caller(skip + 1)
+	if !ok {
+		return e
+	}
+	return e.Str(CallerFileFieldName, zerolog.CallerMarshalFunc(pc, file, line))
+}
+
+// withCallerPC attaches the file:line of pc, as recorded by slog.Record.
+func (c *config) withCallerPC(e *zerolog.Event, pc uintptr) *zerolog.Event {
+	if e == nil || pc == 0 {
+		return e
+	}
+	f, _ := runtime.CallersFrames([]uintptr{pc}).Next()
+	return e.Str(CallerFileFieldName, zerolog.CallerMarshalFunc(f.PC, f.File, f.Line))
+}
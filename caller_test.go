@@ -0,0 +1,65 @@
+package zwrap
+
+import (
+	"bytes"
+	"fmt"
+	"log"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/rs/zerolog"
+)
+
+func TestLogger_ReportCaller(t *testing.T) {
+	buf := new(bytes.Buffer)
+	zl := Wrap(zerolog.New(buf)).WithReportCaller().WithPrefix("prefix").WithNoPanics()
+
+	_, _, line, _ := runtime.Caller(0)
+	zl.Printf("f: %d", 1)
+	zl.Errorf("f: %d", 1)
+	zl.Infoln("yeet")
+	zl.Panic("yeet")
+	zl.Slog().Info("yeet")
+	NewLogrSink(zl).Info(0, "yeet")
+	_, _ = zl.Write([]byte("yeet\n"))
+	_ = zl.Output(1, "yeet")
+	std := log.New(nil, "", 0)
+	CaptureLogger(std, zl)
+	std.Print("yeet")
+
+	ms := decodeLines(t, buf)
+	if len(ms) != 9 {
+		t.Fatalf("expected 9 lines, got %d: %s", len(ms), buf.String())
+	}
+	for i, m := range ms {
+		want := fmt.Sprintf("caller_test.go:%d", line+1+i)
+		if i == len(ms)-1 {
+			want = fmt.Sprintf("caller_test.go:%d", line+1+i+2)
+		}
+		if file, _ := m[CallerFileFieldName].(string); !strings.HasSuffix(file, want) {
+			t.Errorf("line %d: expected %s to end in %s, got %v", i, CallerFileFieldName, want, m)
+		}
+		if m["caller"] != "prefix" {
+			t.Errorf("line %d: expected prefix in caller, got %v", i, m)
+		}
+	}
+}
+
+func TestLogger_Output(t *testing.T) {
+	buf := new(bytes.Buffer)
+	zl := Wrap(zerolog.New(buf))
+	_ = zl.Output(2, "no caller")
+	_ = zl.Output(1, "caller")
+
+	ms := decodeLines(t, buf)
+	if len(ms) != 2 {
+		t.Fatalf("expected 2 lines, got %d: %s", len(ms), buf.String())
+	}
+	if _, ok := ms[0][CallerFileFieldName]; ok {
+		t.Errorf("unexpected caller for calldepth 2: %v", ms[0])
+	}
+	if file, _ := ms[1][CallerFileFieldName].(string); !strings.Contains(file, "caller_test.go:") {
+		t.Errorf("expected caller for calldepth 1, got %v", ms[1])
+	}
+}
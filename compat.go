@@ -16,6 +16,7 @@ type StdCompatLogger interface {
 	Output(calldepth int, s string) error
 }
 
+// GRPCCompatLogger is an interface that provides compatibility with grpclog.LoggerV2.
 type GRPCCompatLogger interface {
 	Info(args ...any)
 	Infoln(args ...any)
@@ -32,14 +33,49 @@ type GRPCCompatLogger interface {
 	V(l int) bool
 }
 
+// GRPCDepthCompatLogger is an interface that provides compatibility with grpclog.DepthLoggerV2.
+// Depth 0 attributes the log line to the caller of the Depth method.
+type GRPCDepthCompatLogger interface {
+	GRPCCompatLogger
+	InfoDepth(depth int, args ...any)
+	WarningDepth(depth int, args ...any)
+	ErrorDepth(depth int, args ...any)
+	FatalDepth(depth int, args ...any)
+}
+
+// SaramaCompatLogger is an interface that provides compatibility with github.com/IBM/sarama.StdLogger,
+// the type of sarama.Logger and sarama.DebugLogger.
+type SaramaCompatLogger interface {
+	Print(v ...interface{})
+	Printf(format string, v ...interface{})
+	Println(v ...interface{})
+}
+
+// NATSCompatLogger is an interface that provides compatibility with github.com/nats-io/nats-server/v2/server.Logger.
+// Noticef logs at info level and Tracef at trace level. Fatalf honours NoFatals, so an embedded server
+// can be kept from exiting the process.
+type NATSCompatLogger interface {
+	Noticef(format string, v ...interface{})
+	Warnf(format string, v ...interface{})
+	Fatalf(format string, v ...interface{})
+	Errorf(format string, v ...interface{})
+	Debugf(format string, v ...interface{})
+	Tracef(format string, v ...interface{})
+}
+
 type ZWrapLogger interface {
 	StdCompatLogger
 	GRPCCompatLogger
+	GRPCDepthCompatLogger
 }
 
-// assert that Logger implements StdCompatLogger and GRPCCompatLogger.
+// assert that Logger implements StdCompatLogger, GRPCCompatLogger, GRPCDepthCompatLogger,
+// SaramaCompatLogger and NATSCompatLogger.
 var (
-	_ StdCompatLogger  = &Logger{}
-	_ GRPCCompatLogger = &Logger{}
-	_ ZWrapLogger      = &Logger{}
+	_ StdCompatLogger       = &Logger{}
+	_ GRPCCompatLogger      = &Logger{}
+	_ GRPCDepthCompatLogger = &Logger{}
+	_ SaramaCompatLogger    = &Logger{}
+	_ NATSCompatLogger      = &Logger{}
+	_ ZWrapLogger           = &Logger{}
 )
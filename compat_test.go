@@ -0,0 +1,67 @@
+package zwrap
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/rs/zerolog"
+)
+
+func TestSaramaCompatLogger(t *testing.T) {
+	buf := new(bytes.Buffer)
+	var sl SaramaCompatLogger = Wrap(zerolog.New(buf))
+
+	sl.Print("client/metadata fetching metadata for all topics from broker", "localhost:9092")
+	sl.Printf("consumer/broker/%d added subscription to %s/%d", 1, "events", 0)
+	sl.Println("Closing Client")
+
+	ms := decodeLines(t, buf)
+	want := []string{
+		"client/metadata fetching metadata for all topics from broker localhost:9092",
+		"consumer/broker/1 added subscription to events/0",
+		"Closing Client",
+	}
+	if len(ms) != len(want) {
+		t.Fatalf("expected %d lines, got %d: %s", len(want), len(ms), buf.String())
+	}
+	for i, w := range want {
+		if ms[i]["level"] != "info" || ms[i]["message"] != w {
+			t.Errorf("line %d: unexpected record: %v", i, ms[i])
+		}
+	}
+}
+
+func TestNATSCompatLogger(t *testing.T) {
+	buf := new(bytes.Buffer)
+	zl := Wrap(zerolog.New(buf).Level(zerolog.TraceLevel))
+	zl.NoFatals(true)
+	var nl NATSCompatLogger = zl
+
+	nl.Noticef("Starting nats-server version %s", "2.10.0")
+	nl.Warnf("slow consumer %d", 7)
+	nl.Errorf("error %s", "boom")
+	nl.Debugf("client connection %d", 1)
+	nl.Tracef("<<- [%s]", "PING")
+	nl.Fatalf("listen %s: address already in use", ":4222")
+
+	ms := decodeLines(t, buf)
+	want := []struct{ level, msg string }{
+		{"info", "Starting nats-server version 2.10.0"},
+		{"warn", "slow consumer 7"},
+		{"error", "error boom"},
+		{"debug", "client connection 1"},
+		{"trace", "<<- [PING]"},
+		{"error", "[FATAL BYPASSED] listen :4222: address already in use"},
+	}
+	if len(ms) != len(want) {
+		t.Fatalf("expected %d lines, got %d: %s", len(want), len(ms), buf.String())
+	}
+	if _, ok := ms[0]["level_alias"]; ok {
+		t.Errorf("expected no level alias field by default, got %v", ms[0])
+	}
+	for i, w := range want {
+		if ms[i]["level"] != w.level || ms[i]["message"] != w.msg {
+			t.Errorf("line %d: got %v, want level %q message %q", i, ms[i], w.level, w.msg)
+		}
+	}
+}
@@ -0,0 +1,60 @@
+package zwrap
+
+import (
+	"net"
+	"sync"
+	"time"
+)
+
+// redialConn is a connection that is dialed on first use and redialed once whenever a write fails,
+// for writers forwarding events to a log server. timeout bounds both dialing and each write, so that a server
+// that stops reading fails writes instead of blocking them.
+type redialConn struct {
+	network string
+	addr    string
+	timeout time.Duration
+
+	mu   sync.Mutex
+	conn net.Conn
+}
+
+// write writes each of msgs in turn, holding the connection for all of them so they are not interleaved
+// with those of other writes.
+func (c *redialConn) write(msgs ...[]byte) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, msg := range msgs {
+		var err error
+		for attempt := 0; attempt < 2; attempt++ {
+			if c.conn == nil {
+				if c.conn, err = net.DialTimeout(c.network, c.addr, c.timeout); err != nil {
+					c.conn = nil
+					continue
+				}
+			}
+			if err = c.conn.SetWriteDeadline(time.Now().Add(c.timeout)); err == nil {
+				if _, err = c.conn.Write(msg); err == nil {
+					break
+				}
+			}
+			_ = c.conn.Close()
+			c.conn = nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Close closes the connection, if any. The next write dials again.
+func (c *redialConn) Close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.conn == nil {
+		return nil
+	}
+	err := c.conn.Close()
+	c.conn = nil
+	return err
+}
@@ -0,0 +1,113 @@
+package zwrap
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+
+	"github.com/rs/zerolog"
+)
+
+// ContextExtractor adds fields taken from ctx, such as a trace ID, tenant or user, to e.
+type ContextExtractor func(ctx context.Context, e *zerolog.Event)
+
+var (
+	extractorsMu sync.Mutex
+	extractors   atomic.Pointer[[]ContextExtractor]
+)
+
+// RegisterContextExtractor adds fn to the extractors run for every event that carries a context,
+// i.e. events logged with the *Context methods, through SlogHandler or through the adapters that take one.
+// Extractors run in the order they were registered, after the event's own fields.
+func RegisterContextExtractor(fn ContextExtractor) {
+	extractorsMu.Lock()
+	defer extractorsMu.Unlock()
+	var fns []ContextExtractor
+	if cur := extractors.Load(); cur != nil {
+		fns = append(fns, *cur...)
+	}
+	fns = append(fns, fn)
+	extractors.Store(&fns)
+}
+
+// contextHook runs the registered ContextExtractors, alongside prefixHook.
+type contextHook struct{}
+
+func (contextHook) Run(e *zerolog.Event, _ zerolog.Level, _ string) {
+	fns := extractors.Load()
+	if fns == nil {
+		return
+	}
+	ctx := e.GetCtx()
+	for _, fn := range *fns {
+		fn(ctx, e)
+	}
+}
+
+type loggerKey struct{}
+
+// NewContext returns a copy of ctx carrying l.
+func NewContext(ctx context.Context, l *Logger) context.Context {
+	return context.WithValue(ctx, loggerKey{}, l)
+}
+
+// FromContext returns the Logger carried by ctx, or a new disabled Logger if there is none.
+func FromContext(ctx context.Context) *Logger {
+	if l, ok := ctx.Value(loggerKey{}).(*Logger); ok {
+		return l
+	}
+	return Wrap(zerolog.Nop())
+}
+
+// withCtx applies the force level to e and attaches ctx, for use with preserve set.
+func (c *config) withCtx(ctx context.Context, e *zerolog.Event) *zerolog.Event {
+	if c.forceLevel != nil {
+		e = c.transformZEvent(e)
+	}
+	return e.Ctx(ctx)
+}
+
+func (l *Logger) TraceContext(ctx context.Context, v ...interface{}) {
+	c := l.config()
+	c.printLn(c.withCtx(ctx, c.zl.Trace()), true, v...)
+}
+
+func (l *Logger) DebugContext(ctx context.Context, v ...interface{}) {
+	c := l.config()
+	c.printLn(c.withCtx(ctx, c.zl.Debug()), true, v...)
+}
+
+func (l *Logger) InfoContext(ctx context.Context, v ...interface{}) {
+	c := l.config()
+	c.printLn(c.withCtx(ctx, c.zl.Info()), true, v...)
+}
+
+func (l *Logger) WarnContext(ctx context.Context, v ...interface{}) {
+	c := l.config()
+	c.printLn(c.withCtx(ctx, c.zl.Warn()), true, v...)
+}
+
+func (l *Logger) ErrorContext(ctx context.Context, v ...interface{}) {
+	c := l.config()
+	c.printLn(c.withCtx(ctx, c.zl.Error()), true, v...)
+}
+
+func (l *Logger) FatalContext(ctx context.Context, v ...interface{}) {
+	c := l.config()
+	var ok bool
+	if _, v, ok = c.checkFatalBypass("", v...); ok {
+		c.printLn(c.zl.Fatal().Ctx(ctx), true, v...)
+		return
+	}
+	c.printLn(c.withCtx(ctx, c.zl.Error()), true, v...)
+}
+
+func (l *Logger) PanicContext(ctx context.Context, v ...interface{}) {
+	c := l.config()
+	var ok bool
+	if _, v, ok = c.checkPanicBypass("", v...); ok {
+		c.printLn(c.zl.Panic().Ctx(ctx), true, v...)
+		return
+	}
+	c.printLn(c.withCtx(ctx, c.zl.Error()), true, v...)
+}
@@ -0,0 +1,76 @@
+package zwrap
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/rs/zerolog"
+)
+
+type tenantKey struct{}
+
+func withTenantExtractor(t *testing.T) {
+	t.Helper()
+	saved := extractors.Load()
+	t.Cleanup(func() { extractors.Store(saved) })
+	RegisterContextExtractor(func(ctx context.Context, e *zerolog.Event) {
+		if tenant, ok := ctx.Value(tenantKey{}).(string); ok {
+			e.Str("tenant", tenant)
+		}
+	})
+}
+
+func TestNewContext(t *testing.T) {
+	zl := Wrap(zerolog.Nop())
+	if got := FromContext(NewContext(context.Background(), zl)); got != zl {
+		t.Errorf("expected FromContext to return the Logger given to NewContext")
+	}
+	fallback := FromContext(context.Background())
+	if fallback == nil || fallback.ZLogger().GetLevel() != zerolog.Disabled {
+		t.Errorf("expected a disabled Logger without one in the context, got %v", fallback)
+	}
+	fallback.Info("must not panic")
+
+	for i := 0; i < 3; i++ {
+		FromContext(context.Background()).WithFields(map[string]interface{}{"n": i}).WithPrefix("x")
+	}
+	if prefix := FromContext(context.Background()).Prefix(); prefix != "" {
+		t.Errorf("configuring one fallback Logger leaked into the next, prefix %q", prefix)
+	}
+}
+
+func TestLogger_Context(t *testing.T) {
+	withTenantExtractor(t)
+	buf := new(bytes.Buffer)
+	zl := Wrap(zerolog.New(buf)).WithPrefix("svc")
+	ctx := context.WithValue(context.Background(), tenantKey{}, "acme")
+
+	zl.InfoContext(ctx, "hello")
+	zl.Info("no context")
+	zl.ErrorContext(context.Background(), "no tenant")
+	zl.NoFatals(true)
+	zl.FatalContext(ctx, "bypassed")
+	zl.ForceLevel(zerolog.WarnLevel)
+	zl.DebugContext(ctx, "forced")
+	zl.Slog().InfoContext(ctx, "via slog")
+
+	ms := decodeLines(t, buf)
+	want := []struct{ level, msg, tenant string }{
+		{"info", "hello", "acme"},
+		{"info", "no context", ""},
+		{"error", "no tenant", ""},
+		{"error", "[FATAL BYPASSED] bypassed", "acme"},
+		{"warn", "forced", "acme"},
+		{"warn", "via slog", "acme"},
+	}
+	if len(ms) != len(want) {
+		t.Fatalf("expected %d lines, got %d: %s", len(want), len(ms), buf.String())
+	}
+	for i, w := range want {
+		tenant, _ := ms[i]["tenant"].(string)
+		if ms[i]["level"] != w.level || ms[i]["message"] != w.msg || tenant != w.tenant || ms[i]["caller"] != "svc" {
+			t.Errorf("line %d: got %v, want %+v", i, ms[i], w)
+		}
+	}
+}
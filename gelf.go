@@ -0,0 +1,196 @@
+package zwrap
+
+import (
+	"bytes"
+	"compress/gzip"
+	"compress/zlib"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+)
+
+// GELFCompression selects how GELF messages sent over UDP are compressed.
+type GELFCompression int
+
+const (
+	GELFCompressNone GELFCompression = iota
+	GELFCompressGzip
+	GELFCompressZlib
+)
+
+// GELFOptions configure a GELFWriter.
+type GELFOptions struct {
+	// Network is "udp" or "tcp", or one of their variants such as "udp4".
+	Network string
+	Addr    string
+	// Host is the host field of every message, os.Hostname if empty.
+	Host string
+	// Compression applies to UDP only, Graylog's TCP input takes uncompressed, null-delimited messages.
+	Compression GELFCompression
+	// ChunkSize is the largest UDP datagram sent, 1420 if zero or too small to hold a chunk header.
+	// Larger messages are split into up to 128 chunks.
+	ChunkSize int
+	// DialTimeout bounds each (re)connection attempt and each write, 5 seconds if zero.
+	DialTimeout time.Duration
+}
+
+// GELFWriter is an io.Writer that forwards zerolog JSON events to Graylog as GELF 1.1 messages.
+// The first line of the message becomes short_message, and the whole message full_message if it has more
+// than one line. The level is mapped to a syslog severity as by SyslogWriter, and every other field is sent as
+// an additional field, prefixed with "_"; nested objects and arrays are sent as JSON strings.
+type GELFWriter struct {
+	opts GELFOptions
+	conn *redialConn
+}
+
+// NewGELFWriter returns a GELFWriter sending to opts.Addr.
+func NewGELFWriter(opts GELFOptions) *GELFWriter {
+	if opts.Host == "" {
+		opts.Host, _ = os.Hostname()
+	}
+	if opts.ChunkSize <= gelfChunkHeader {
+		opts.ChunkSize = 1420
+	}
+	if opts.DialTimeout == 0 {
+		opts.DialTimeout = 5 * time.Second
+	}
+	return &GELFWriter{
+		opts: opts,
+		conn: &redialConn{network: opts.Network, addr: opts.Addr, timeout: opts.DialTimeout},
+	}
+}
+
+func (w *GELFWriter) Write(p []byte) (n int, err error) {
+	err = splitLines(p, func(line []byte) error {
+		r, err := parseRecord(line)
+		if err != nil {
+			return err
+		}
+		msg, err := w.message(r)
+		if err != nil {
+			return err
+		}
+		return w.send(msg)
+	})
+	if err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// Close closes the connection to Graylog, if any.
+func (w *GELFWriter) Close() error {
+	return w.conn.Close()
+}
+
+func (w *GELFWriter) message(r record) ([]byte, error) {
+	m := make(map[string]interface{}, len(r.fields)+6)
+	m["version"] = "1.1"
+	m["host"] = w.opts.Host
+	m["level"] = syslogSeverity(r)
+	dropLevelAlias(r)
+
+	t := r.time
+	if t.IsZero() {
+		t = time.Now()
+	}
+	m["timestamp"] = json.Number(fmt.Sprintf("%d.%06d", t.Unix(), t.Nanosecond()/1000))
+
+	short, _, multiline := strings.Cut(r.msg, "\n")
+	if short == "" {
+		// short_message is required and must not be empty.
+		short = "-"
+	}
+	m["short_message"] = short
+	if multiline {
+		m["full_message"] = r.msg
+	}
+
+	for k, v := range r.fields {
+		switch v := v.(type) {
+		case json.Number, string:
+			m[gelfFieldName(k)] = v
+		default:
+			m[gelfFieldName(k)] = syslogValue(v)
+		}
+	}
+	return json.Marshal(m)
+}
+
+// gelfFieldName returns the additional field name for key, replacing characters GELF disallows with '_'.
+// Graylog reserves _id, so id is sent as _id_.
+func gelfFieldName(key string) string {
+	key = strings.Map(func(r rune) rune {
+		if r == '.' || r == '-' || r == '_' || 'a' <= r && r <= 'z' || 'A' <= r && r <= 'Z' || '0' <= r && r <= '9' {
+			return r
+		}
+		return '_'
+	}, key)
+	if key == "id" {
+		return "_id_"
+	}
+	return "_" + key
+}
+
+var errGELFTooLarge = errors.New("gelf: message needs more than 128 chunks")
+
+// gelfChunkHeader is the size of the magic bytes, message ID, sequence number and count starting each chunk.
+const gelfChunkHeader = 12
+
+func (w *GELFWriter) send(msg []byte) error {
+	if !strings.HasPrefix(w.opts.Network, "udp") {
+		return w.conn.write(append(msg, 0))
+	}
+
+	var buf bytes.Buffer
+	switch w.opts.Compression {
+	case GELFCompressGzip:
+		zw := gzip.NewWriter(&buf)
+		_, _ = zw.Write(msg)
+		if err := zw.Close(); err != nil {
+			return err
+		}
+		msg = buf.Bytes()
+	case GELFCompressZlib:
+		zw := zlib.NewWriter(&buf)
+		_, _ = zw.Write(msg)
+		if err := zw.Close(); err != nil {
+			return err
+		}
+		msg = buf.Bytes()
+	}
+	if len(msg) <= w.opts.ChunkSize {
+		return w.conn.write(msg)
+	}
+	chunks, err := gelfChunks(msg, w.opts.ChunkSize)
+	if err != nil {
+		return err
+	}
+	return w.conn.write(chunks...)
+}
+
+// gelfChunks splits msg into datagrams of at most size bytes, each starting with the chunk magic bytes,
+// a message ID shared by all chunks, the chunk's sequence number and the number of chunks.
+func gelfChunks(msg []byte, size int) ([][]byte, error) {
+	per := size - gelfChunkHeader
+	count := (len(msg) + per - 1) / per
+	if count > 128 {
+		return nil, errGELFTooLarge
+	}
+	var id [8]byte
+	_, _ = rand.Read(id[:])
+	chunks := make([][]byte, 0, count)
+	for seq := 0; seq < count; seq++ {
+		part := msg[seq*per : min(len(msg), (seq+1)*per)]
+		chunk := make([]byte, 0, gelfChunkHeader+len(part))
+		chunk = append(chunk, 0x1e, 0x0f)
+		chunk = append(chunk, id[:]...)
+		chunk = append(chunk, byte(seq), byte(count))
+		chunks = append(chunks, append(chunk, part...))
+	}
+	return chunks, nil
+}
@@ -0,0 +1,176 @@
+package zwrap
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"compress/zlib"
+	"encoding/json"
+	"io"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+func TestGELFFieldName(t *testing.T) {
+	tests := []struct{ in, want string }{
+		{"user", "_user"},
+		{"http.status-code", "_http.status-code"},
+		{"bad key!", "_bad_key_"},
+		{"id", "_id_"},
+	}
+	for _, tt := range tests {
+		if got := gelfFieldName(tt.in); got != tt.want {
+			t.Errorf("gelfFieldName(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+// readGELFDatagrams reads datagrams from pc until they make up one message, reassembling chunks.
+func readGELFDatagrams(t *testing.T, pc net.PacketConn) (msg []byte, chunks int) {
+	t.Helper()
+	_ = pc.SetReadDeadline(time.Now().Add(5 * time.Second))
+	buf := make([]byte, 65536)
+	var parts [][]byte
+	for {
+		n, _, err := pc.ReadFrom(buf)
+		if err != nil {
+			t.Fatal(err)
+		}
+		d := append([]byte(nil), buf[:n]...)
+		if len(d) < 2 || d[0] != 0x1e || d[1] != 0x0f {
+			return d, 0
+		}
+		if parts == nil {
+			parts = make([][]byte, d[11])
+		}
+		parts[d[10]] = d[12:]
+		chunks++
+		if chunks == len(parts) {
+			return bytes.Join(parts, nil), chunks
+		}
+	}
+}
+
+func TestGELFWriter_UDP(t *testing.T) {
+	pc, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer pc.Close()
+
+	tests := []struct {
+		name        string
+		compression GELFCompression
+		decompress  func(io.Reader) (io.Reader, error)
+	}{
+		{"none", GELFCompressNone, func(r io.Reader) (io.Reader, error) { return r, nil }},
+		{"gzip", GELFCompressGzip, func(r io.Reader) (io.Reader, error) { return gzip.NewReader(r) }},
+		{"zlib", GELFCompressZlib, func(r io.Reader) (io.Reader, error) { return zlib.NewReader(r) }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := NewGELFWriter(GELFOptions{Network: "udp", Addr: pc.LocalAddr().String(), Host: "host",
+				Compression: tt.compression, ChunkSize: 200})
+			defer w.Close()
+			zl := Wrap(zerolog.New(w).With().Timestamp().Logger())
+
+			zl.Child().WithFields(map[string]interface{}{"user": "kayos", "n": 2, "id": "x",
+				"nested": map[string]interface{}{"k": "v"}}).Error("short")
+			// the repetitive body compresses below the chunk size, so only the uncompressed message is chunked.
+			long := "first line\n" + strings.Repeat("0123456789abcdef", 128)
+			zl.Warn(long)
+
+			for i, want := range []struct {
+				short, full string
+				level       float64
+				chunked     bool
+			}{
+				{"short", "", SyslogError, false},
+				{"first line", long, SyslogWarning, tt.compression == GELFCompressNone},
+			} {
+				raw, chunks := readGELFDatagrams(t, pc)
+				if (chunks > 0) != want.chunked {
+					t.Errorf("message %d: chunked = %v, want %v", i, chunks > 0, want.chunked)
+				}
+				r, err := tt.decompress(bytes.NewReader(raw))
+				if err != nil {
+					t.Fatal(err)
+				}
+				var m map[string]interface{}
+				if err := json.NewDecoder(r).Decode(&m); err != nil {
+					t.Fatalf("message %d: %v", i, err)
+				}
+				full, _ := m["full_message"].(string)
+				if m["version"] != "1.1" || m["host"] != "host" || m["short_message"] != want.short ||
+					full != want.full || m["level"] != want.level || m["timestamp"] == nil {
+					t.Errorf("message %d: unexpected GELF message: %v", i, m)
+				}
+				if i == 0 && (m["_user"] != "kayos" || m["_n"] != float64(2) || m["_id_"] != "x" || m["_nested"] != `{"k":"v"}`) {
+					t.Errorf("unexpected additional fields: %v", m)
+				}
+			}
+		})
+	}
+}
+
+func TestGELFWriter_UDPTooLarge(t *testing.T) {
+	w := NewGELFWriter(GELFOptions{Network: "udp", Addr: "127.0.0.1:9", ChunkSize: 13})
+	defer w.Close()
+	if _, err := w.Write([]byte(`{"message":"` + strings.Repeat("x", 200) + `"}`)); err != errGELFTooLarge {
+		t.Errorf("expected errGELFTooLarge, got %v", err)
+	}
+}
+
+func TestNewGELFWriter_ChunkSize(t *testing.T) {
+	for _, size := range []int{-1, 0, 1, 12} {
+		if got := NewGELFWriter(GELFOptions{Network: "udp", ChunkSize: size}).opts.ChunkSize; got != 1420 {
+			t.Errorf("ChunkSize %d: got %d, want the default", size, got)
+		}
+	}
+	if got := NewGELFWriter(GELFOptions{Network: "udp", ChunkSize: 13}).opts.ChunkSize; got != 13 {
+		t.Errorf("ChunkSize 13: got %d, want it kept", got)
+	}
+}
+
+func TestGELFWriter_TCP(t *testing.T) {
+	withLevelAliases(t)
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ln.Close()
+
+	w := NewGELFWriter(GELFOptions{Network: "tcp", Addr: ln.Addr().String(), Host: "host", Compression: GELFCompressGzip})
+	defer w.Close()
+	zl := Wrap(zerolog.New(w))
+	zl.Noticef("one")
+	zl.Info("two")
+
+	c, err := ln.Accept()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+	_ = c.SetReadDeadline(time.Now().Add(5 * time.Second))
+	r := bufio.NewReader(c)
+	for _, want := range []struct {
+		msg   string
+		level float64
+	}{{"one", SyslogNotice}, {"two", SyslogInfo}} {
+		frame, err := r.ReadBytes(0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var m map[string]interface{}
+		if err := json.Unmarshal(frame[:len(frame)-1], &m); err != nil {
+			t.Fatalf("expected an uncompressed, null-delimited message: %v", err)
+		}
+		if m["short_message"] != want.msg || m["level"] != want.level || m["_level_alias"] != nil {
+			t.Errorf("unexpected GELF message: %v", m)
+		}
+	}
+}
@@ -1,11 +1,13 @@
 module git.tcp.direct/kayos/zwrap
 
-go 1.20
+go 1.21
 
-require github.com/rs/zerolog v1.32.0
+require (
+	github.com/rs/zerolog v1.32.0
+	golang.org/x/sys v0.20.0
+)
 
 require (
 	github.com/mattn/go-colorable v0.1.13 // indirect
 	github.com/mattn/go-isatty v0.0.19 // indirect
-	golang.org/x/sys v0.20.0 // indirect
 )
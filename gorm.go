@@ -0,0 +1,157 @@
+package zwrap
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+// GormLogLevel mirrors gorm.io/gorm/logger.LogLevel.
+type GormLogLevel int
+
+const (
+	GormSilent GormLogLevel = iota + 1
+	GormError
+	GormWarn
+	GormInfo
+)
+
+// Field names used by GormLogger.Trace.
+var (
+	GormSQLFieldName           = "sql"
+	GormRowsFieldName          = "rows"
+	GormElapsedFieldName       = "elapsed"
+	GormSlowThresholdFieldName = "slow_threshold"
+)
+
+// GormConfig mirrors the parts of gorm.io/gorm/logger.Config that apply to GormLogger.
+type GormConfig struct {
+	// SlowThreshold escalates traced queries taking longer than it to warn level. Zero disables it.
+	SlowThreshold time.Duration
+	// IgnoreRecordNotFoundError drops traces whose error is gorm's ErrRecordNotFound.
+	IgnoreRecordNotFoundError bool
+	LogLevel                  GormLogLevel
+}
+
+// GormCompatLogger mirrors the method set of gorm.io/gorm/logger.Interface without importing gorm.
+// As with LogrCompatSink, LogMode refers to gorm's own types and needs a small shim in the importing package.
+type GormCompatLogger interface {
+	LogMode(level GormLogLevel) GormCompatLogger
+	Info(ctx context.Context, msg string, data ...interface{})
+	Warn(ctx context.Context, msg string, data ...interface{})
+	Error(ctx context.Context, msg string, data ...interface{})
+	Trace(ctx context.Context, begin time.Time, fc func() (sql string, rowsAffected int64), err error)
+}
+
+var _ GormCompatLogger = (*GormLogger)(nil)
+
+// GormLogger adapts a Logger to gorm's logger.Interface. Its LogLevel filters events before the Logger's own
+// level does, so the Logger may stay shared with other code.
+type GormLogger struct {
+	l   *Logger
+	cfg GormConfig
+}
+
+// NewGormLogger returns a GormLogger writing through l. A zero LogLevel is treated as GormWarn, gorm's default.
+func NewGormLogger(l *Logger, cfg GormConfig) *GormLogger {
+	if cfg.LogLevel == 0 {
+		cfg.LogLevel = GormWarn
+	}
+	return &GormLogger{l: l, cfg: cfg}
+}
+
+// gormToZlogLevel maps gorm levels onto zerolog levels, gorm's Error through Info are zerolog's mirrored around 2.5.
+func gormToZlogLevel(level GormLogLevel) zerolog.Level {
+	if level <= GormSilent {
+		return zerolog.Disabled
+	}
+	return castToZlogLevel(5 - int(level))
+}
+
+// isGormRecordNotFound reports whether err is, or wraps, gorm's ErrRecordNotFound, which is matched by its message.
+func isGormRecordNotFound(err error) bool {
+	for ; err != nil; err = errors.Unwrap(err) {
+		if err.Error() == "record not found" {
+			return true
+		}
+	}
+	return false
+}
+
+// LogMode returns a copy of g logging at level, g itself is left as is.
+func (g *GormLogger) LogMode(level GormLogLevel) GormCompatLogger {
+	ng := *g
+	ng.cfg.LogLevel = level
+	return &ng
+}
+
+func (g *GormLogger) allows(level zerolog.Level) bool {
+	return g.cfg.LogLevel > GormSilent && level >= gormToZlogLevel(g.cfg.LogLevel)
+}
+
+func (g *GormLogger) Info(ctx context.Context, msg string, data ...interface{}) {
+	if g.allows(zerolog.InfoLevel) {
+		c := g.l.config()
+		c.printf(c.withCtx(ctx, c.zl.Info()), true, msg, data...)
+	}
+}
+
+func (g *GormLogger) Warn(ctx context.Context, msg string, data ...interface{}) {
+	if g.allows(zerolog.WarnLevel) {
+		c := g.l.config()
+		c.printf(c.withCtx(ctx, c.zl.Warn()), true, msg, data...)
+	}
+}
+
+func (g *GormLogger) Error(ctx context.Context, msg string, data ...interface{}) {
+	if g.allows(zerolog.ErrorLevel) {
+		c := g.l.config()
+		c.printf(c.withCtx(ctx, c.zl.Error()), true, msg, data...)
+	}
+}
+
+// Trace logs a finished query with its SQL, affected rows and elapsed time: at error level if it failed,
+// at warn level if it exceeded SlowThreshold, and at info level otherwise. fc is only called if the event
+// is written. Rows are omitted when gorm reports -1.
+func (g *GormLogger) Trace(ctx context.Context, begin time.Time, fc func() (sql string, rowsAffected int64), err error) {
+	if g.cfg.LogLevel <= GormSilent {
+		return
+	}
+	elapsed := time.Since(begin)
+	slow := g.cfg.SlowThreshold != 0 && elapsed > g.cfg.SlowThreshold
+
+	var level zerolog.Level
+	var msg string
+	switch {
+	case err != nil && !(g.cfg.IgnoreRecordNotFoundError && isGormRecordNotFound(err)):
+		level, msg = zerolog.ErrorLevel, "query failed"
+	case slow:
+		level, msg = zerolog.WarnLevel, "slow query"
+	default:
+		level, msg = zerolog.InfoLevel, "query"
+	}
+	if !g.allows(level) {
+		return
+	}
+
+	c := g.l.config()
+	e := c.withAutoCaller(c.event(level).Ctx(ctx))
+	if e == nil {
+		return
+	}
+	sql, rows := fc()
+	e.Str(GormSQLFieldName, sql)
+	if rows != -1 {
+		e.Int64(GormRowsFieldName, rows)
+	}
+	e.Dur(GormElapsedFieldName, elapsed)
+	if slow {
+		e.Dur(GormSlowThresholdFieldName, g.cfg.SlowThreshold)
+	}
+	if level == zerolog.ErrorLevel {
+		e.AnErr(zerolog.ErrorFieldName, err)
+	}
+	e.Msg(msg)
+}
@@ -0,0 +1,70 @@
+package zwrap
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+func TestGormToZlogLevel(t *testing.T) {
+	tests := []struct {
+		in   GormLogLevel
+		want zerolog.Level
+	}{
+		{GormSilent, zerolog.Disabled},
+		{GormError, zerolog.ErrorLevel},
+		{GormWarn, zerolog.WarnLevel},
+		{GormInfo, zerolog.InfoLevel},
+	}
+	for _, tt := range tests {
+		if got := gormToZlogLevel(tt.in); got != tt.want {
+			t.Errorf("gormToZlogLevel(%d) = %v, want %v", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestGormLogger_Trace(t *testing.T) {
+	buf := new(bytes.Buffer)
+	gl := NewGormLogger(Wrap(zerolog.New(buf)), GormConfig{
+		SlowThreshold:             time.Millisecond,
+		IgnoreRecordNotFoundError: true,
+		LogLevel:                  GormInfo,
+	})
+	ctx := context.Background()
+	fc := func() (string, int64) { return "SELECT 1", 1 }
+	notFound := fmt.Errorf("find user: %w", errors.New("record not found"))
+
+	gl.Trace(ctx, time.Now(), fc, nil)
+	gl.Trace(ctx, time.Now().Add(-time.Second), fc, nil)
+	gl.Trace(ctx, time.Now(), func() (string, int64) { return "SELECT x", -1 }, errors.New("no such column"))
+	gl.Trace(ctx, time.Now(), fc, notFound)
+	gl.LogMode(GormError).Trace(ctx, time.Now().Add(-time.Second), fc, nil)
+	gl.LogMode(GormSilent).Trace(ctx, time.Now(), fc, errors.New("dropped"))
+	gl.LogMode(GormWarn).Info(ctx, "dropped %d", 1)
+	gl.Warn(ctx, "kept %d", 1)
+
+	ms := decodeLines(t, buf)
+	if len(ms) != 5 {
+		t.Fatalf("expected 5 lines, got %d: %s", len(ms), buf.String())
+	}
+	if ms[0]["level"] != "info" || ms[0]["sql"] != "SELECT 1" || ms[0]["rows"] != float64(1) || ms[0]["elapsed"] == nil {
+		t.Errorf("unexpected query record: %v", ms[0])
+	}
+	if ms[1]["level"] != "warn" || ms[1]["slow_threshold"] == nil {
+		t.Errorf("unexpected slow query record: %v", ms[1])
+	}
+	if ms[2]["level"] != "error" || ms[2]["error"] != "no such column" || ms[2]["rows"] != nil {
+		t.Errorf("unexpected failed query record: %v", ms[2])
+	}
+	if ms[3]["level"] != "info" || ms[3]["error"] != nil {
+		t.Errorf("expected record not found to be ignored, got %v", ms[3])
+	}
+	if ms[4]["level"] != "warn" || ms[4]["message"] != "kept 1" {
+		t.Errorf("unexpected warn record: %v", ms[4])
+	}
+}
@@ -0,0 +1,73 @@
+package zwrap
+
+import (
+	"os"
+	"strconv"
+
+	"github.com/rs/zerolog"
+)
+
+// GRPCVerbosityEnv is the environment variable grpc-go reads its verbosity level from.
+const GRPCVerbosityEnv = "GRPC_GO_LOG_VERBOSITY_LEVEL"
+
+// VerbosityFromEnv returns the verbosity level set in GRPCVerbosityEnv, or 0 if it is unset or invalid.
+func VerbosityFromEnv() int {
+	v, err := strconv.Atoi(os.Getenv(GRPCVerbosityEnv))
+	if err != nil {
+		return 0
+	}
+	return v
+}
+
+// V reports whether verbosity level level is enabled, following grpclog's convention:
+// V(level) is true when level is less than or equal to the verbosity set with SetVerbosity.
+// The default verbosity is 0.
+func (l *Logger) V(level int) bool {
+	return level <= l.config().verbosity
+}
+
+// SetVerbosity sets the threshold used by V and by LogrSink's V-levels, see VerbosityFromEnv.
+func (l *Logger) SetVerbosity(v int) {
+	l.update(func(c *config) {
+		c.verbosity = v
+	})
+}
+
+func (l *Logger) WithVerbosity(v int) *Logger {
+	l = l.withTarget()
+	l.SetVerbosity(v)
+	return l
+}
+
+func (l *Logger) InfoDepth(depth int, args ...any) {
+	c := l.config()
+	c.printDepth(c.zl.Info(), false, depth, args...)
+}
+
+func (l *Logger) WarningDepth(depth int, args ...any) {
+	c := l.config()
+	c.printDepth(c.zl.Warn(), false, depth, args...)
+}
+
+func (l *Logger) ErrorDepth(depth int, args ...any) {
+	c := l.config()
+	c.printDepth(c.zl.Error(), false, depth, args...)
+}
+
+func (l *Logger) FatalDepth(depth int, args ...any) {
+	c := l.config()
+	var ok bool
+	if _, args, ok = c.checkFatalBypass("", args...); ok {
+		c.printDepth(c.zl.Fatal(), true, depth, args...)
+		return
+	}
+	c.printDepth(c.zl.Error(), false, depth, args...)
+}
+
+// printDepth is printLn with the file:line of the caller depth frames above the exported method attached.
+func (c *config) printDepth(e *zerolog.Event, preserve bool, depth int, v ...interface{}) {
+	if c.forceLevel != nil && !preserve {
+		e = c.transformZEvent(e)
+	}
+	send(c.withCaller(e, depth+2), v...)
+}
@@ -0,0 +1,62 @@
+package zwrap
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/rs/zerolog"
+)
+
+func TestLogger_V(t *testing.T) {
+	zl := Wrap(zerolog.Nop())
+	t.Setenv(GRPCVerbosityEnv, "2")
+	zl.SetVerbosity(VerbosityFromEnv())
+	for level, want := range map[int]bool{-1: true, 0: true, 2: true, 3: false} {
+		if got := zl.V(level); got != want {
+			t.Errorf("V(%d) = %v, want %v", level, got, want)
+		}
+	}
+	t.Setenv(GRPCVerbosityEnv, "yeet")
+	if VerbosityFromEnv() != 0 {
+		t.Error("expected invalid verbosity to fall back to 0")
+	}
+}
+
+func depthHelper(zl *Logger) {
+	zl.WarningDepth(1, "from helper")
+}
+
+func TestLogger_Depth(t *testing.T) {
+	buf := new(bytes.Buffer)
+	zl := Wrap(zerolog.New(buf))
+
+	zl.InfoDepth(0, "direct")
+	depthHelper(zl)
+	zl.SetPrefix("grpc")
+	zl.ErrorDepth(0, "prefixed")
+	zl.NoFatals(true)
+	zl.FatalDepth(0, "not fatal")
+	zl.ForceLevel(zerolog.DebugLevel)
+	zl.InfoDepth(0, "forced")
+
+	ms := decodeLines(t, buf)
+	if len(ms) != 5 {
+		t.Fatalf("expected 5 lines, got %d: %s", len(ms), buf.String())
+	}
+	for i, m := range ms {
+		file, _ := m[CallerFileFieldName].(string)
+		if !strings.Contains(file, "grpc_test.go:") {
+			t.Errorf("line %d: expected %s to point at grpc_test.go, got %v", i, CallerFileFieldName, m)
+		}
+	}
+	if ms[2]["caller"] != "grpc" {
+		t.Errorf("expected prefix to be kept in caller, got %v", ms[2])
+	}
+	if ms[3]["level"] != "error" || ms[3]["message"] != "[FATAL BYPASSED] not fatal" {
+		t.Errorf("expected bypassed fatal, got %v", ms[3])
+	}
+	if ms[4]["level"] != "debug" {
+		t.Errorf("expected forced level to be kept, got %v", ms[4])
+	}
+}
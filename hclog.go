@@ -0,0 +1,242 @@
+package zwrap
+
+import (
+	"bytes"
+	"io"
+	"log"
+	"strings"
+
+	"github.com/rs/zerolog"
+)
+
+// HCLevel mirrors hclog.Level.
+type HCLevel int32
+
+const (
+	HCNoLevel HCLevel = iota
+	HCTrace
+	HCDebug
+	HCInfo
+	HCWarn
+	HCError
+	HCOff
+)
+
+// HCStandardLoggerOptions mirrors hclog.StandardLoggerOptions.
+type HCStandardLoggerOptions struct {
+	InferLevels              bool
+	InferLevelsWithTimestamp bool
+	ForceLevel               HCLevel
+}
+
+// HCLogCompatLogger mirrors the method set of hashicorp/go-hclog.Logger without importing hclog.
+// As with LogrCompatSink, methods referring to hclog's own types need a small shim in the importing package.
+type HCLogCompatLogger interface {
+	Log(level HCLevel, msg string, args ...interface{})
+	Trace(msg string, args ...interface{})
+	Debug(msg string, args ...interface{})
+	Info(msg string, args ...interface{})
+	Warn(msg string, args ...interface{})
+	Error(msg string, args ...interface{})
+	IsTrace() bool
+	IsDebug() bool
+	IsInfo() bool
+	IsWarn() bool
+	IsError() bool
+	ImpliedArgs() []interface{}
+	With(args ...interface{}) HCLogCompatLogger
+	Name() string
+	Named(name string) HCLogCompatLogger
+	ResetNamed(name string) HCLogCompatLogger
+	SetLevel(level HCLevel)
+	GetLevel() HCLevel
+	StandardLogger(opts *HCStandardLoggerOptions) *log.Logger
+	StandardWriter(opts *HCStandardLoggerOptions) io.Writer
+}
+
+var _ HCLogCompatLogger = (*HCLogger)(nil)
+
+// HCLogger adapts a Logger to the hclog.Logger method set. The logger name is the Logger's prefix,
+// with Named joining names with ".", and arguments given to With become fields of a Child.
+type HCLogger struct {
+	l    *Logger
+	args []interface{}
+}
+
+// NewHCLogger returns an HCLogger writing through l.
+func NewHCLogger(l *Logger) *HCLogger {
+	return &HCLogger{l: l}
+}
+
+// hclogToZlogLevel maps hclog levels onto zerolog levels, hclog's Trace through Error are zerolog's shifted by two.
+func hclogToZlogLevel(level HCLevel) zerolog.Level {
+	switch level {
+	case HCNoLevel:
+		return zerolog.NoLevel
+	case HCOff:
+		return zerolog.Disabled
+	default:
+		return castToZlogLevel(int(level) - 2)
+	}
+}
+
+func zlogToHCLevel(level zerolog.Level) HCLevel {
+	switch {
+	case level == zerolog.Disabled:
+		return HCOff
+	case level == zerolog.NoLevel:
+		return HCNoLevel
+	case level < zerolog.TraceLevel:
+		return HCTrace
+	case level > zerolog.ErrorLevel:
+		return HCError
+	default:
+		return HCLevel(level + 2)
+	}
+}
+
+func (h *HCLogger) Log(level HCLevel, msg string, args ...interface{}) {
+	if level == HCOff {
+		return
+	}
+	c := h.l.config()
+	c.printw(c.event(hclogToZlogLevel(level)), true, msg, args)
+}
+
+func (h *HCLogger) Trace(msg string, args ...interface{}) {
+	h.l.Tracew(msg, args...)
+}
+
+func (h *HCLogger) Debug(msg string, args ...interface{}) {
+	h.l.Debugw(msg, args...)
+}
+
+func (h *HCLogger) Info(msg string, args ...interface{}) {
+	h.l.Infow(msg, args...)
+}
+
+func (h *HCLogger) Warn(msg string, args ...interface{}) {
+	h.l.Warnw(msg, args...)
+}
+
+func (h *HCLogger) Error(msg string, args ...interface{}) {
+	h.l.Errorw(msg, args...)
+}
+
+func (h *HCLogger) IsTrace() bool {
+	return h.l.config().enabled(zerolog.TraceLevel)
+}
+
+func (h *HCLogger) IsDebug() bool {
+	return h.l.config().enabled(zerolog.DebugLevel)
+}
+
+func (h *HCLogger) IsInfo() bool {
+	return h.l.config().enabled(zerolog.InfoLevel)
+}
+
+func (h *HCLogger) IsWarn() bool {
+	return h.l.config().enabled(zerolog.WarnLevel)
+}
+
+func (h *HCLogger) IsError() bool {
+	return h.l.config().enabled(zerolog.ErrorLevel)
+}
+
+func (h *HCLogger) ImpliedArgs() []interface{} {
+	return h.args
+}
+
+func (h *HCLogger) With(args ...interface{}) HCLogCompatLogger {
+	nl := h.l.Child()
+	nl.update(func(c *config) {
+		c.base = c.base.With().Fields(normalizeKeyvals(args)).Logger()
+	})
+	nargs := make([]interface{}, 0, len(h.args)+len(args))
+	nargs = append(append(nargs, h.args...), args...)
+	return &HCLogger{l: nl, args: nargs}
+}
+
+func (h *HCLogger) Name() string {
+	return h.l.Prefix()
+}
+
+func (h *HCLogger) Named(name string) HCLogCompatLogger {
+	if prefix := h.l.Prefix(); prefix != "" {
+		name = prefix + "." + name
+	}
+	return h.ResetNamed(name)
+}
+
+func (h *HCLogger) ResetNamed(name string) HCLogCompatLogger {
+	nl := h.l.Child()
+	nl.SetPrefix(name)
+	return &HCLogger{l: nl, args: h.args}
+}
+
+// SetLevel sets the level of the underlying Logger, HCNoLevel is treated as info.
+func (h *HCLogger) SetLevel(level HCLevel) {
+	if level == HCNoLevel {
+		level = HCInfo
+	}
+	h.l.SetLevel(hclogToZlogLevel(level))
+}
+
+func (h *HCLogger) GetLevel() HCLevel {
+	return zlogToHCLevel(h.l.ZLogger().GetLevel())
+}
+
+func (h *HCLogger) StandardLogger(opts *HCStandardLoggerOptions) *log.Logger {
+	return log.New(h.StandardWriter(opts), "", 0)
+}
+
+func (h *HCLogger) StandardWriter(opts *HCStandardLoggerOptions) io.Writer {
+	if opts == nil {
+		opts = &HCStandardLoggerOptions{}
+	}
+	return &hclogWriter{h: h, opts: *opts}
+}
+
+type hclogWriter struct {
+	h    *HCLogger
+	opts HCStandardLoggerOptions
+}
+
+var hclogLevelTags = []struct {
+	tag   string
+	level HCLevel
+}{
+	{"[TRACE]", HCTrace},
+	{"[DEBUG]", HCDebug},
+	{"[INFO]", HCInfo},
+	{"[WARN]", HCWarn},
+	{"[ERROR]", HCError},
+	{"[ERR]", HCError},
+}
+
+// inferLevel strips a leading "[LEVEL]" tag, as written by hclog's standard logger, from line.
+func inferLevel(line string, withTimestamp bool) (string, HCLevel) {
+	trimmed := line
+	if withTimestamp {
+		trimmed = parseStdLogLine(trimmed, log.LstdFlags, "").msg
+	}
+	for _, t := range hclogLevelTags {
+		if strings.HasPrefix(trimmed, t.tag) {
+			return strings.TrimSpace(trimmed[len(t.tag):]), t.level
+		}
+	}
+	return line, HCInfo
+}
+
+func (w *hclogWriter) Write(p []byte) (n int, err error) {
+	msg := string(bytes.TrimRight(p, "\r\n"))
+	level := HCInfo
+	if w.opts.InferLevels || w.opts.InferLevelsWithTimestamp {
+		msg, level = inferLevel(msg, w.opts.InferLevelsWithTimestamp)
+	}
+	if w.opts.ForceLevel != HCNoLevel {
+		level = w.opts.ForceLevel
+	}
+	w.h.Log(level, msg)
+	return len(p), nil
+}
@@ -0,0 +1,62 @@
+package zwrap
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+
+	"github.com/rs/zerolog"
+)
+
+func TestHCLogLevels(t *testing.T) {
+	for level := HCTrace; level <= HCOff; level++ {
+		if got := zlogToHCLevel(hclogToZlogLevel(level)); got != level {
+			t.Errorf("level %d did not survive a round trip, got %d", level, got)
+		}
+	}
+	if hclogToZlogLevel(HCWarn) != zerolog.WarnLevel {
+		t.Errorf("expected HCWarn to map to zerolog.WarnLevel")
+	}
+}
+
+func TestHCLogger(t *testing.T) {
+	buf := new(bytes.Buffer)
+	zl := Wrap(zerolog.New(buf))
+	var h HCLogCompatLogger = NewHCLogger(zl)
+
+	h.SetLevel(HCDebug)
+	if h.GetLevel() != HCDebug || h.IsTrace() || !h.IsDebug() {
+		t.Fatalf("unexpected level state: %d", h.GetLevel())
+	}
+
+	named := h.Named("raft").Named("fsm").With("node", 1)
+	if named.Name() != "raft.fsm" || h.Name() != "" {
+		t.Errorf("unexpected names %q and %q", named.Name(), h.Name())
+	}
+	if !reflect.DeepEqual(named.ImpliedArgs(), []interface{}{"node", 1}) {
+		t.Errorf("unexpected implied args %v", named.ImpliedArgs())
+	}
+
+	named.Info("applied", "index", 3)
+	named.Trace("dropped")
+	h.Log(HCWarn, "logged", "k", "v")
+	h.ResetNamed("plugin").StandardLogger(&HCStandardLoggerOptions{InferLevels: true}).Print("[ERROR] oops")
+	h.StandardWriter(&HCStandardLoggerOptions{InferLevelsWithTimestamp: true}).Write([]byte("2024/01/02 03:04:05 [DEBUG] yeet\n"))
+
+	ms := decodeLines(t, buf)
+	if len(ms) != 4 {
+		t.Fatalf("expected 4 lines, got %d: %s", len(ms), buf.String())
+	}
+	if ms[0]["caller"] != "raft.fsm" || ms[0]["node"] != float64(1) || ms[0]["index"] != float64(3) || ms[0]["level"] != "info" {
+		t.Errorf("unexpected record: %v", ms[0])
+	}
+	if ms[1]["level"] != "warn" || ms[1]["k"] != "v" || ms[1]["caller"] != nil {
+		t.Errorf("unexpected record: %v", ms[1])
+	}
+	if ms[2]["level"] != "error" || ms[2]["message"] != "oops" || ms[2]["caller"] != "plugin" {
+		t.Errorf("unexpected record: %v", ms[2])
+	}
+	if ms[3]["level"] != "debug" || ms[3]["message"] != "yeet" {
+		t.Errorf("unexpected record: %v", ms[3])
+	}
+}
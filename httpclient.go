@@ -0,0 +1,84 @@
+package zwrap
+
+import (
+	"fmt"
+	"time"
+)
+
+// RetryableHTTPCompatLogger mirrors github.com/hashicorp/go-retryablehttp.LeveledLogger.
+type RetryableHTTPCompatLogger interface {
+	Error(msg string, keysAndValues ...interface{})
+	Info(msg string, keysAndValues ...interface{})
+	Debug(msg string, keysAndValues ...interface{})
+	Warn(msg string, keysAndValues ...interface{})
+}
+
+// RestyCompatLogger mirrors github.com/go-resty/resty.Logger.
+type RestyCompatLogger interface {
+	Errorf(format string, v ...interface{})
+	Warnf(format string, v ...interface{})
+	Debugf(format string, v ...interface{})
+}
+
+var (
+	_ RetryableHTTPCompatLogger = (*HTTPClientLogger)(nil)
+	_ RestyCompatLogger         = (*HTTPClientLogger)(nil)
+)
+
+// HTTPClientLogger adapts a Logger to the leveled loggers of HTTP client libraries. Keys and values are
+// written as fields; values that only implement fmt.Stringer, such as *url.URL, are written as their string
+// rather than as a JSON object.
+type HTTPClientLogger struct {
+	l *Logger
+}
+
+// NewHTTPClientLogger returns an HTTPClientLogger writing through l.
+func NewHTTPClientLogger(l *Logger) *HTTPClientLogger {
+	return &HTTPClientLogger{l: l}
+}
+
+// stringifyKeyvals replaces fmt.Stringer values that zerolog has no encoding for with their string.
+func stringifyKeyvals(kv []interface{}) []interface{} {
+	kv = normalizeKeyvals(kv)
+	copied := false
+	for i := 1; i < len(kv); i += 2 {
+		switch v := kv[i].(type) {
+		case error, time.Duration, time.Time:
+		case fmt.Stringer:
+			if !copied {
+				kv = append([]interface{}(nil), kv...)
+				copied = true
+			}
+			kv[i] = v.String()
+		}
+	}
+	return kv
+}
+
+func (h *HTTPClientLogger) Error(msg string, keysAndValues ...interface{}) {
+	h.l.Errorw(msg, stringifyKeyvals(keysAndValues)...)
+}
+
+func (h *HTTPClientLogger) Info(msg string, keysAndValues ...interface{}) {
+	h.l.Infow(msg, stringifyKeyvals(keysAndValues)...)
+}
+
+func (h *HTTPClientLogger) Debug(msg string, keysAndValues ...interface{}) {
+	h.l.Debugw(msg, stringifyKeyvals(keysAndValues)...)
+}
+
+func (h *HTTPClientLogger) Warn(msg string, keysAndValues ...interface{}) {
+	h.l.Warnw(msg, stringifyKeyvals(keysAndValues)...)
+}
+
+func (h *HTTPClientLogger) Errorf(format string, v ...interface{}) {
+	h.l.Errorf(format, v...)
+}
+
+func (h *HTTPClientLogger) Warnf(format string, v ...interface{}) {
+	h.l.Warnf(format, v...)
+}
+
+func (h *HTTPClientLogger) Debugf(format string, v ...interface{}) {
+	h.l.Debugf(format, v...)
+}
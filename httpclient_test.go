@@ -0,0 +1,39 @@
+package zwrap
+
+import (
+	"bytes"
+	"errors"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+func TestHTTPClientLogger(t *testing.T) {
+	buf := new(bytes.Buffer)
+	hl := NewHTTPClientLogger(Wrap(zerolog.New(buf).Level(zerolog.DebugLevel)))
+	u, _ := url.Parse("https://example.com/api?q=1")
+
+	hl.Debug("performing request", "method", "GET", "url", u)
+	hl.Warn("retrying request", "request", "GET https://example.com/api", "timeout", 2*time.Second, "remaining", 3)
+	hl.Error("request failed", "error", errors.New("connection refused"))
+	hl.Errorf("resty: %s", "boom")
+
+	ms := decodeLines(t, buf)
+	if len(ms) != 4 {
+		t.Fatalf("expected 4 lines, got %d: %s", len(ms), buf.String())
+	}
+	if ms[0]["level"] != "debug" || ms[0]["url"] != u.String() || ms[0]["method"] != "GET" {
+		t.Errorf("unexpected record: %v", ms[0])
+	}
+	if ms[1]["level"] != "warn" || ms[1]["timeout"] != float64(2000) || ms[1]["remaining"] != float64(3) {
+		t.Errorf("unexpected record: %v", ms[1])
+	}
+	if ms[2]["level"] != "error" || ms[2]["error"] != "connection refused" {
+		t.Errorf("unexpected record: %v", ms[2])
+	}
+	if ms[3]["level"] != "error" || ms[3]["message"] != "resty: boom" {
+		t.Errorf("unexpected record: %v", ms[3])
+	}
+}
@@ -0,0 +1,157 @@
+package zwrap
+
+import (
+	"encoding/binary"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/rs/zerolog"
+)
+
+// DefaultJournaldSocket is the socket journald reads native protocol entries from.
+const DefaultJournaldSocket = "/run/systemd/journal/socket"
+
+// JournaldOptions configure a JournaldWriter.
+type JournaldOptions struct {
+	// Socket is the path of journald's socket, DefaultJournaldSocket if empty.
+	Socket string
+	// Identifier is the SYSLOG_IDENTIFIER of events without a prefix, the base name of the executable if empty.
+	Identifier string
+}
+
+// JournaldWriter is an io.Writer that forwards zerolog JSON events to systemd-journald using its native protocol.
+// The message becomes MESSAGE, the level PRIORITY (see syslogSeverity), the Logger's prefix SYSLOG_IDENTIFIER,
+// the file:line written by ReportCaller CODE_FILE and CODE_LINE, and every other field a journal field named
+// after it in upper case. journald stamps entries with its own time, so the event's timestamp is dropped.
+//
+// Entries too large for a datagram are passed to journald as a sealed memfd, or an unlinked temporary file
+// where memfds are unavailable. This is only supported on Linux.
+type JournaldWriter struct {
+	opts JournaldOptions
+
+	mu   sync.Mutex
+	conn *net.UnixConn
+}
+
+// NewJournaldWriter returns a JournaldWriter sending to opts.Socket.
+func NewJournaldWriter(opts JournaldOptions) *JournaldWriter {
+	if opts.Socket == "" {
+		opts.Socket = DefaultJournaldSocket
+	}
+	if opts.Identifier == "" {
+		opts.Identifier = filepath.Base(os.Args[0])
+	}
+	return &JournaldWriter{opts: opts}
+}
+
+func (w *JournaldWriter) Write(p []byte) (n int, err error) {
+	err = splitLines(p, func(line []byte) error {
+		r, err := parseRecord(line)
+		if err != nil {
+			return err
+		}
+		return w.send(w.entry(r))
+	})
+	if err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// Close closes the connection to journald, if any.
+func (w *JournaldWriter) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.conn == nil {
+		return nil
+	}
+	err := w.conn.Close()
+	w.conn = nil
+	return err
+}
+
+func (w *JournaldWriter) send(entry []byte) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	var err error
+	for attempt := 0; attempt < 2; attempt++ {
+		if w.conn == nil {
+			if w.conn, err = net.DialUnix("unixgram", nil, &net.UnixAddr{Name: w.opts.Socket, Net: "unixgram"}); err != nil {
+				w.conn = nil
+				continue
+			}
+		}
+		if _, err = w.conn.Write(entry); err == nil {
+			return nil
+		}
+		if isMsgTooLarge(err) {
+			return sendJournalFd(w.conn, entry)
+		}
+		_ = w.conn.Close()
+		w.conn = nil
+	}
+	return err
+}
+
+func (w *JournaldWriter) entry(r record) []byte {
+	b := make([]byte, 0, 256)
+	b = appendJournalField(b, "PRIORITY", string(rune('0'+syslogSeverity(r))))
+	dropLevelAlias(r)
+
+	identifier := w.opts.Identifier
+	if prefix, ok := r.fields[zerolog.CallerFieldName].(string); ok && prefix != "" {
+		identifier = prefix
+		delete(r.fields, zerolog.CallerFieldName)
+	}
+	b = appendJournalField(b, "SYSLOG_IDENTIFIER", identifier)
+
+	if caller, ok := r.fields[CallerFileFieldName].(string); ok {
+		if i := strings.LastIndexByte(caller, ':'); i > 0 {
+			b = appendJournalField(b, "CODE_FILE", caller[:i])
+			b = appendJournalField(b, "CODE_LINE", caller[i+1:])
+			delete(r.fields, CallerFileFieldName)
+		}
+	}
+
+	for _, k := range sortedKeys(r.fields) {
+		b = appendJournalField(b, journalFieldName(k), syslogValue(r.fields[k]))
+	}
+	return appendJournalField(b, "MESSAGE", r.msg)
+}
+
+// journalFieldName turns key into a valid journal field name: upper case letters, digits and underscores,
+// not starting with an underscore or digit, at most 64 characters.
+func journalFieldName(key string) string {
+	name := []byte(strings.ToUpper(key))
+	for i, c := range name {
+		if !('A' <= c && c <= 'Z' || '0' <= c && c <= '9') {
+			name[i] = '_'
+		}
+	}
+	n := strings.TrimLeft(string(name), "_")
+	if n == "" || ('0' <= n[0] && n[0] <= '9') {
+		n = "F_" + n
+	}
+	if len(n) > 64 {
+		n = n[:64]
+	}
+	return n
+}
+
+// appendJournalField appends a field in the native protocol's format, which needs the binary form for
+// values containing newlines.
+func appendJournalField(b []byte, name, value string) []byte {
+	b = append(b, name...)
+	if !strings.ContainsRune(value, '\n') {
+		b = append(b, '=')
+		b = append(b, value...)
+		return append(b, '\n')
+	}
+	b = append(b, '\n')
+	b = binary.LittleEndian.AppendUint64(b, uint64(len(value)))
+	b = append(b, value...)
+	return append(b, '\n')
+}
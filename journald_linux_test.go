@@ -0,0 +1,46 @@
+package zwrap
+
+import (
+	"io"
+	"os"
+	"strings"
+	"syscall"
+	"testing"
+
+	"github.com/rs/zerolog"
+)
+
+func TestJournaldWriter_Oversized(t *testing.T) {
+	conn, path := listenJournal(t)
+	w := NewJournaldWriter(JournaldOptions{Socket: path, Identifier: "app"})
+	defer w.Close()
+
+	big := strings.Repeat("x", 4<<20)
+	Wrap(zerolog.New(w)).Info(big)
+
+	oob := make([]byte, syscall.CmsgSpace(4))
+	n, oobn, _, _, err := conn.ReadMsgUnix(make([]byte, 16), oob)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != 0 {
+		t.Fatalf("expected an empty datagram carrying a file descriptor, got %d bytes", n)
+	}
+	msgs, err := syscall.ParseSocketControlMessage(oob[:oobn])
+	if err != nil || len(msgs) != 1 {
+		t.Fatalf("expected one control message, got %v, %v", msgs, err)
+	}
+	fds, err := syscall.ParseUnixRights(&msgs[0])
+	if err != nil || len(fds) != 1 {
+		t.Fatalf("expected one file descriptor, got %v, %v", fds, err)
+	}
+	f := os.NewFile(uintptr(fds[0]), "entry")
+	defer f.Close()
+	entry, err := io.ReadAll(io.NewSectionReader(f, 0, 8<<20))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if e := parseJournalEntry(t, entry); e["MESSAGE"] != big || e["PRIORITY"] != "6" {
+		t.Errorf("unexpected entry of %d bytes", len(entry))
+	}
+}
@@ -0,0 +1,16 @@
+//go:build !linux
+
+package zwrap
+
+import (
+	"errors"
+	"net"
+)
+
+func isMsgTooLarge(error) bool {
+	return false
+}
+
+func sendJournalFd(*net.UnixConn, []byte) error {
+	return errors.New("journald: passing entries as file descriptors is only supported on linux")
+}
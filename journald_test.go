@@ -0,0 +1,99 @@
+package zwrap
+
+import (
+	"bytes"
+	"encoding/binary"
+	"net"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+func TestJournalFieldName(t *testing.T) {
+	tests := []struct{ in, want string }{
+		{"user", "USER"},
+		{"caller_file", "CALLER_FILE"},
+		{"http.status", "HTTP_STATUS"},
+		{"_private", "PRIVATE"},
+		{"1st", "F_1ST"},
+		{"", "F_"},
+		{strings.Repeat("a", 70), strings.Repeat("A", 64)},
+	}
+	for _, tt := range tests {
+		if got := journalFieldName(tt.in); got != tt.want {
+			t.Errorf("journalFieldName(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+// parseJournalEntry decodes a native protocol entry, including binary-form fields.
+func parseJournalEntry(t *testing.T, b []byte) map[string]string {
+	t.Helper()
+	fields := map[string]string{}
+	for len(b) > 0 {
+		nl := bytes.IndexByte(b, '\n')
+		if nl < 0 {
+			t.Fatalf("unterminated field: %q", b)
+		}
+		if eq := bytes.IndexByte(b[:nl], '='); eq >= 0 {
+			fields[string(b[:eq])] = string(b[eq+1 : nl])
+			b = b[nl+1:]
+			continue
+		}
+		name := string(b[:nl])
+		n := binary.LittleEndian.Uint64(b[nl+1 : nl+9])
+		fields[name] = string(b[nl+9 : nl+9+int(n)])
+		b = b[nl+9+int(n)+1:]
+	}
+	return fields
+}
+
+func listenJournal(t *testing.T) (*net.UnixConn, string) {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "journal.sock")
+	conn, err := net.ListenUnixgram("unixgram", &net.UnixAddr{Name: path, Net: "unixgram"})
+	if err != nil {
+		t.Skipf("unixgram sockets unavailable: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	_ = conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	return conn, path
+}
+
+func TestJournaldWriter(t *testing.T) {
+	withLevelAliases(t)
+	conn, path := listenJournal(t)
+	w := NewJournaldWriter(JournaldOptions{Socket: path, Identifier: "app"})
+	defer w.Close()
+	zl := Wrap(zerolog.New(w).With().Timestamp().Logger())
+
+	zl.Child().WithReportCaller().WithPrefix("billing").WithFields(map[string]interface{}{"user": "kayos", "n": 2}).
+		Warn("line one\nline two")
+	zl.Noticef("plain")
+
+	buf := make([]byte, 4096)
+	var entries []map[string]string
+	for i := 0; i < 2; i++ {
+		n, err := conn.Read(buf)
+		if err != nil {
+			t.Fatal(err)
+		}
+		entries = append(entries, parseJournalEntry(t, buf[:n]))
+	}
+
+	e := entries[0]
+	if e["PRIORITY"] != "4" || e["SYSLOG_IDENTIFIER"] != "billing" || e["MESSAGE"] != "line one\nline two" ||
+		e["USER"] != "kayos" || e["N"] != "2" || !strings.HasSuffix(e["CODE_FILE"], "journald_test.go") || e["CODE_LINE"] == "" {
+		t.Errorf("unexpected entry: %v", e)
+	}
+	if _, ok := e["TIME"]; ok {
+		t.Errorf("expected the timestamp to be dropped: %v", e)
+	}
+	e = entries[1]
+	if e["PRIORITY"] != "5" || e["SYSLOG_IDENTIFIER"] != "app" || e["MESSAGE"] != "plain" || len(e) != 3 {
+		t.Errorf("unexpected entry: %v", e)
+	}
+}
@@ -0,0 +1,133 @@
+package zwrap
+
+import (
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+// KitCompatLogger is an interface that provides compatibility with github.com/go-kit/log.Logger.
+type KitCompatLogger interface {
+	Log(keyvals ...interface{}) error
+}
+
+var _ KitCompatLogger = (*KitLogger)(nil)
+
+// KitLogger adapts a Logger to go-kit's Log(keyvals...) convention. The level, msg or message, caller,
+// ts and err keys are taken out of keyvals and written as the event level, message, CallerFileFieldName,
+// KitTimestampFieldName and zerolog.ErrorFieldName. The remaining pairs become fields.
+// Events without a level key, or with one zerolog cannot parse, are logged at the Logger's print level.
+// go-kit's level values, such as level.WarnValue(), are parsed from their string form.
+type KitLogger struct {
+	l  *Logger
+	ew *kitErrWriter
+	// mu keeps the error of one event from being reported by another Log call.
+	mu sync.Mutex
+}
+
+// KitTimestampFieldName is the field go-kit's ts value is written to. It differs from zerolog.TimestampFieldName
+// so that it does not clash with the time stamped by a Logger created with .With().Timestamp().
+var KitTimestampFieldName = "ts"
+
+// NewKitLogger returns a KitLogger writing through l. zerolog does not report write errors to its callers,
+// so to have Log return them, w must be the writer l was created with, including any ConsoleWriter or
+// MultiLevelWriter around it: events are then written to w instead of l's output, with l's current
+// configuration. If w is nil, Log only ever returns nil.
+func NewKitLogger(l *Logger, w io.Writer) *KitLogger {
+	k := &KitLogger{l: l}
+	if w != nil {
+		k.ew = &kitErrWriter{w: w}
+	}
+	return k
+}
+
+// kitErrWriter records the error of a write instead of leaving it to zerolog.ErrorHandler.
+type kitErrWriter struct {
+	w   io.Writer
+	err error
+}
+
+func (w *kitErrWriter) Write(p []byte) (int, error) {
+	if _, err := w.w.Write(p); err != nil && w.err == nil {
+		w.err = err
+	}
+	return len(p), nil
+}
+
+func (k *KitLogger) Log(keyvals ...interface{}) error {
+	c := k.l.config()
+	if k.ew != nil {
+		k.mu.Lock()
+		defer k.mu.Unlock()
+		k.ew.err = nil
+		zl := c.zl.Output(k.ew)
+		nc := *c
+		nc.zl = &zl
+		c = &nc
+	}
+
+	keyvals = normalizeKeyvals(keyvals)
+	level := c.printLevel
+	var lvl, msg, caller, ts, errv interface{}
+	rest := make([]interface{}, 0, len(keyvals))
+	for i := 0; i < len(keyvals); i += 2 {
+		key, val := keyvals[i].(string), keyvals[i+1]
+		switch {
+		case key == "level" && lvl == nil:
+			lvl = val
+		case (key == "msg" || key == "message") && msg == nil:
+			msg = val
+		case key == "caller" && caller == nil:
+			caller = val
+		case key == "ts" && ts == nil:
+			ts = val
+		case key == "err" && errv == nil:
+			errv = val
+		default:
+			rest = append(rest, key, val)
+		}
+	}
+
+	if lvl != nil {
+		if parsed, err := zerolog.ParseLevel(fmt.Sprint(lvl)); err == nil && parsed != zerolog.NoLevel {
+			level = parsed
+		}
+	}
+	e := c.event(level)
+	if e == nil {
+		return nil
+	}
+	if caller != nil {
+		e.Str(CallerFileFieldName, fmt.Sprint(caller))
+	} else {
+		e = c.withAutoCaller(e)
+	}
+	switch t := ts.(type) {
+	case nil:
+	case time.Time:
+		e.Time(KitTimestampFieldName, t)
+	default:
+		e.Str(KitTimestampFieldName, fmt.Sprint(t))
+	}
+	switch err := errv.(type) {
+	case nil:
+	case error:
+		e.AnErr(zerolog.ErrorFieldName, err)
+	default:
+		e.Interface(zerolog.ErrorFieldName, err)
+	}
+	e.Fields(rest)
+	if msg != nil {
+		send(e, msg)
+	} else {
+		e.Send()
+	}
+
+	if k.ew != nil {
+		return k.ew.err
+	}
+	return nil
+}
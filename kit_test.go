@@ -0,0 +1,108 @@
+package zwrap
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+type kitLevel string
+
+func (l kitLevel) String() string { return string(l) }
+
+func TestKitLogger_Log(t *testing.T) {
+	buf := new(bytes.Buffer)
+	kl := NewKitLogger(Wrap(zerolog.New(buf)), buf)
+	ts := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	if err := kl.Log("level", kitLevel("warn"), "msg", "hello", "caller", "main.go:12",
+		"ts", ts, "err", errors.New("boom"), "user", "kayos", "count", 2); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := kl.Log("message", "no level", "level", "bogus"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	ms := decodeLines(t, buf)
+	if len(ms) != 2 {
+		t.Fatalf("expected 2 lines, got %d: %s", len(ms), buf.String())
+	}
+	want := map[string]interface{}{
+		"level": "warn", "message": "hello", CallerFileFieldName: "main.go:12",
+		KitTimestampFieldName: ts.Format(zerolog.TimeFieldFormat), "error": "boom",
+		"user": "kayos", "count": float64(2),
+	}
+	for k, v := range want {
+		if ms[0][k] != v {
+			t.Errorf("%s: got %v, want %v", k, ms[0][k], v)
+		}
+	}
+	if ms[1]["level"] != "info" || ms[1]["message"] != "no level" {
+		t.Errorf("unexpected record: %v", ms[1])
+	}
+}
+
+type failingWriter struct{}
+
+func (failingWriter) Write(p []byte) (int, error) {
+	return 0, errors.New("disk full")
+}
+
+func TestKitLogger_WriteError(t *testing.T) {
+	kl := NewKitLogger(Wrap(zerolog.New(failingWriter{})), failingWriter{})
+	if err := kl.Log("msg", "hello"); err == nil || err.Error() != "disk full" {
+		t.Errorf("expected write error, got %v", err)
+	}
+
+	kl = NewKitLogger(Wrap(zerolog.New(failingWriter{}).Level(zerolog.ErrorLevel)), failingWriter{})
+	if err := kl.Log("level", "debug", "msg", "dropped"); err != nil {
+		t.Errorf("expected no error for a disabled event, got %v", err)
+	}
+}
+
+func TestKitLogger_OwnTimestamp(t *testing.T) {
+	buf := new(bytes.Buffer)
+	kl := NewKitLogger(Wrap(zerolog.New(buf).With().Timestamp().Logger()), buf)
+	if err := kl.Log("ts", time.Unix(0, 0).UTC(), "msg", "hello"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if n := bytes.Count(buf.Bytes(), []byte(`"`+zerolog.TimestampFieldName+`":`)); n != 1 {
+		t.Errorf("expected one %s key, got %d: %s", zerolog.TimestampFieldName, n, buf.String())
+	}
+	if ms := decodeLines(t, buf); ms[0][KitTimestampFieldName] != "1970-01-01T00:00:00Z" {
+		t.Errorf("expected go-kit's ts under %s, got %v", KitTimestampFieldName, ms[0])
+	}
+}
+
+func TestKitLogger_FollowsConfig(t *testing.T) {
+	buf := new(bytes.Buffer)
+	zl := Wrap(zerolog.New(buf))
+	kl := NewKitLogger(zl, buf)
+	zl.SetLevel(zerolog.ErrorLevel)
+	zl.SetPrefix("later")
+	if err := kl.Log("level", "debug", "msg", "dropped"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := kl.Log("level", "error", "msg", "kept"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	ms := decodeLines(t, buf)
+	if len(ms) != 1 || ms[0]["message"] != "kept" || ms[0]["caller"] != "later" {
+		t.Errorf("expected the KitLogger to follow later configuration changes, got %s", buf.String())
+	}
+}
+
+func TestKitLogger_WrappedWriter(t *testing.T) {
+	buf := new(bytes.Buffer)
+	cw := zerolog.ConsoleWriter{Out: buf, NoColor: true, PartsExclude: []string{zerolog.TimestampFieldName}}
+	kl := NewKitLogger(Wrap(zerolog.New(cw)), cw)
+	if err := kl.Log("level", "warn", "msg", "hello"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := buf.String(); got != "WRN hello\n" {
+		t.Errorf("expected the console writer's format, got %q", got)
+	}
+}
@@ -0,0 +1,125 @@
+package zwrap
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/rs/zerolog"
+)
+
+// BadKey is the key used for a trailing value that is missing its key.
+const BadKey = "!BADKEY"
+
+// normalizeKeyvals turns alternating keys and values into a list zerolog's Fields accepts:
+//   - keys that are not strings are formatted with fmt.Sprint,
+//   - an error in key position is taken as the value of zerolog.ErrorFieldName and consumes no value,
+//   - a trailing value without a key is kept under BadKey.
+//
+// kv is returned as is if it is already well-formed.
+func normalizeKeyvals(kv []interface{}) []interface{} {
+	wellFormed := len(kv)%2 == 0
+	for i := 0; wellFormed && i < len(kv); i += 2 {
+		_, wellFormed = kv[i].(string)
+	}
+	if wellFormed {
+		return kv
+	}
+
+	nkv := make([]interface{}, 0, len(kv)+2)
+	for i := 0; i < len(kv); i++ {
+		switch key := kv[i].(type) {
+		case string:
+			if i == len(kv)-1 {
+				nkv = append(nkv, BadKey, key)
+				continue
+			}
+			nkv = append(nkv, key, kv[i+1])
+			i++
+		case error:
+			nkv = append(nkv, zerolog.ErrorFieldName, key)
+		default:
+			if i == len(kv)-1 {
+				nkv = append(nkv, BadKey, key)
+				continue
+			}
+			nkv = append(nkv, fmt.Sprint(key), kv[i+1])
+			i++
+		}
+	}
+	return nkv
+}
+
+// KeyValues controls whether Print, Println, Info, Error and the other non-format methods treat their
+// arguments as a message followed by alternating keys and values, as go-kit, hclog and zap's sugared
+// logger do, instead of joining all of them into the message. Only calls with more than one argument
+// whose first argument is a string are affected. See normalizeKeyvals for how malformed pairs are handled.
+func (l *Logger) KeyValues(b bool) {
+	l.update(func(c *config) {
+		c.keyValues = b
+	})
+}
+
+func (l *Logger) WithKeyValues() *Logger {
+	l = l.withTarget()
+	l.KeyValues(true)
+	return l
+}
+
+// kvMessage returns the message of a call in key/value mode, if v is one.
+func (c *config) kvMessage(v []interface{}) (string, bool) {
+	if !c.keyValues || len(v) < 2 {
+		return "", false
+	}
+	msg, ok := v[0].(string)
+	return msg, ok
+}
+
+func (c *config) printw(e *zerolog.Event, preserve bool, msg string, keysAndValues []interface{}) {
+	if e = c.prepare(e, preserve); e == nil {
+		return
+	}
+	e.Fields(normalizeKeyvals(keysAndValues)).Msg(msg)
+}
+
+func (l *Logger) Tracew(msg string, keysAndValues ...interface{}) {
+	c := l.config()
+	c.printw(c.zl.Trace(), false, msg, keysAndValues)
+}
+
+func (l *Logger) Debugw(msg string, keysAndValues ...interface{}) {
+	c := l.config()
+	c.printw(c.zl.Debug(), false, msg, keysAndValues)
+}
+
+func (l *Logger) Infow(msg string, keysAndValues ...interface{}) {
+	c := l.config()
+	c.printw(c.zl.Info(), false, msg, keysAndValues)
+}
+
+func (l *Logger) Warnw(msg string, keysAndValues ...interface{}) {
+	c := l.config()
+	c.printw(c.zl.Warn(), false, msg, keysAndValues)
+}
+
+func (l *Logger) Errorw(msg string, keysAndValues ...interface{}) {
+	c := l.config()
+	c.printw(c.zl.Error(), false, msg, keysAndValues)
+}
+
+func (l *Logger) Fatalw(msg string, keysAndValues ...interface{}) {
+	c := l.config()
+	if !c.noFatal {
+		c.printw(c.zl.Fatal(), true, msg, keysAndValues)
+		return
+	}
+	c.printw(c.zl.Error(), false, strings.TrimSpace("[FATAL BYPASSED] "+msg), keysAndValues)
+}
+
+func (l *Logger) Panicw(msg string, keysAndValues ...interface{}) {
+	c := l.config()
+	if !c.noPanic {
+		c.printw(c.zl.Panic(), true, msg, keysAndValues)
+		return
+	}
+	c.printw(c.zl.Error(), false, strings.TrimSpace("[PANIC BYPASSED] "+msg), keysAndValues)
+}
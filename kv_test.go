@@ -0,0 +1,72 @@
+package zwrap
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/rs/zerolog"
+)
+
+func TestNormalizeKeyvals(t *testing.T) {
+	err := errors.New("boom")
+	tests := []struct {
+		name string
+		kv   []interface{}
+		want []interface{}
+	}{
+		{"empty", nil, nil},
+		{"well formed", []interface{}{"a", 1, "b", "two"}, []interface{}{"a", 1, "b", "two"}},
+		{"odd", []interface{}{"a", 1, "b"}, []interface{}{"a", 1, BadKey, "b"}},
+		{"non-string key", []interface{}{1, "one", "a", 2}, []interface{}{"1", "one", "a", 2}},
+		{"odd non-string", []interface{}{"a", 1, 2.5}, []interface{}{"a", 1, BadKey, 2.5}},
+		{"error key", []interface{}{err, "a", 1}, []interface{}{zerolog.ErrorFieldName, err, "a", 1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := normalizeKeyvals(tt.kv); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("normalizeKeyvals(%v) = %v, want %v", tt.kv, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLogger_KeyValues(t *testing.T) {
+	buf := new(bytes.Buffer)
+	zl := Wrap(zerolog.New(buf))
+
+	zl.Infow("w", "n", 1, "ok", true, "err", errors.New("boom"))
+	zl.Info("joined", "n", 1)
+	zl.KeyValues(true)
+	zl.Info("kv", "n", 1, "dangling")
+	zl.Info(1, "n")
+	zl.NoPanics(true)
+	zl.Panic("bypassed", "n", 1)
+	zl.NoFatals(true)
+	zl.Fatalw("", "k", 1)
+
+	ms := decodeLines(t, buf)
+	if len(ms) != 6 {
+		t.Fatalf("expected 6 lines, got %d: %s", len(ms), buf.String())
+	}
+	if ms[0]["message"] != "w" || ms[0]["n"] != float64(1) || ms[0]["ok"] != true || ms[0]["err"] != "boom" {
+		t.Errorf("unexpected Infow record: %v", ms[0])
+	}
+	if ms[1]["message"] != "joined n 1" {
+		t.Errorf("expected arguments to be joined outside of key/value mode: %v", ms[1])
+	}
+	if ms[2]["message"] != "kv" || ms[2]["n"] != float64(1) || ms[2][BadKey] != "dangling" {
+		t.Errorf("unexpected key/value record: %v", ms[2])
+	}
+	if ms[3]["message"] != "1 n" {
+		t.Errorf("expected non-string message to be joined: %v", ms[3])
+	}
+	if ms[4]["message"] != "[PANIC BYPASSED] bypassed" || ms[4]["n"] != float64(1) || ms[4]["level"] != "error" {
+		t.Errorf("unexpected bypassed record: %v", ms[4])
+	}
+	if ms[5]["message"] != "[FATAL BYPASSED]" || ms[5]["k"] != float64(1) || ms[5]["level"] != "error" {
+		t.Errorf("unexpected bypassed Fatalw record: %v", ms[5])
+	}
+}
@@ -2,6 +2,7 @@ package zwrap
 
 import (
 	"fmt"
+	"log/slog"
 
 	"github.com/rs/zerolog"
 )
@@ -40,11 +41,30 @@ func castToZlogLevel(level any) zerolog.Level {
 		}
 	case zerolog.Level:
 		return casted
+	case slog.Level:
+		return slogToZlogLevel(casted)
 	default:
 		panic(fmt.Sprintf("invalid log level type (%T): %v", level, level))
 	}
 }
 
+// slogToZlogLevel maps slog levels, which are spaced 4 apart with info at 0, onto zerolog levels.
+// Anything below slog.LevelDebug is treated as trace, anything above slog.LevelError as error.
+func slogToZlogLevel(level slog.Level) zerolog.Level {
+	switch {
+	case level < slog.LevelDebug:
+		return zerolog.TraceLevel
+	case level < slog.LevelInfo:
+		return zerolog.DebugLevel
+	case level < slog.LevelWarn:
+		return zerolog.InfoLevel
+	case level < slog.LevelError:
+		return zerolog.WarnLevel
+	default:
+		return zerolog.ErrorLevel
+	}
+}
+
 func toZlogLevel[T Level](level T) zerolog.Level {
 	switch casted := any(level).(type) {
 	case uint32: // compat
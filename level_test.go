@@ -1,6 +1,7 @@
 package zwrap
 
 import (
+	"log/slog"
 	"testing"
 
 	"github.com/rs/zerolog"
@@ -106,3 +107,26 @@ func TestToZlogLevel(t *testing.T) {
 		})
 	}
 }
+
+func TestSlogToZlogLevel(t *testing.T) {
+	tests := []struct {
+		level slog.Level
+		want  zerolog.Level
+	}{
+		{slog.LevelDebug - 4, zerolog.TraceLevel},
+		{slog.LevelDebug, zerolog.DebugLevel},
+		{slog.LevelInfo, zerolog.InfoLevel},
+		{slog.LevelInfo + 2, zerolog.InfoLevel},
+		{slog.LevelWarn, zerolog.WarnLevel},
+		{slog.LevelError, zerolog.ErrorLevel},
+		{slog.LevelError + 4, zerolog.ErrorLevel},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.level.String(), func(t *testing.T) {
+			if got := castToZlogLevel(tt.level); got != tt.want {
+				t.Errorf("castToZlogLevel(%v) = %v, want %v", tt.level, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,104 @@
+package zwrap
+
+import (
+	"github.com/rs/zerolog"
+)
+
+// LogrRuntimeInfo mirrors logr.RuntimeInfo.
+type LogrRuntimeInfo struct {
+	CallDepth int
+}
+
+// LogrCompatSink mirrors the method set of go-logr/logr.LogSink without importing logr.
+//
+// Because logr.LogSink refers to logr's own types, a LogrSink is handed to logr.New through
+// a small shim in the importing package:
+//
+//	type sink struct{ zwrap.LogrCompatSink }
+//
+//	func (s sink) Init(ri logr.RuntimeInfo)          { s.LogrCompatSink.Init(zwrap.LogrRuntimeInfo(ri)) }
+//	func (s sink) WithValues(kv ...any) logr.LogSink { return sink{s.LogrCompatSink.WithValues(kv...)} }
+//	func (s sink) WithName(name string) logr.LogSink { return sink{s.LogrCompatSink.WithName(name)} }
+//
+//	log := logr.New(sink{zwrap.NewLogrSink(zl)})
+type LogrCompatSink interface {
+	Init(info LogrRuntimeInfo)
+	Enabled(level int) bool
+	Info(level int, msg string, keysAndValues ...any)
+	Error(err error, msg string, keysAndValues ...any)
+	WithValues(keysAndValues ...any) LogrCompatSink
+	WithName(name string) LogrCompatSink
+}
+
+var _ LogrCompatSink = (*LogrSink)(nil)
+
+// LogrSink adapts a Logger to the logr.LogSink method set. Names given to WithName are joined
+// with "/" and written to the Logger's prefix ("caller") field, values given to WithValues are
+// added as fields. Neither mutates the parent Logger.
+//
+// V-levels follow Logger.V: V(n) is enabled only if n is within the Logger's verbosity, see SetVerbosity,
+// and the zerolog level it maps onto is enabled.
+type LogrSink struct {
+	l         *Logger
+	callDepth int
+}
+
+// NewLogrSink returns a LogrSink writing through l.
+func NewLogrSink(l *Logger) *LogrSink {
+	return &LogrSink{l: l}
+}
+
+// logrToZlogLevel maps logr verbosity onto zerolog levels: V(0) is info, V(1) is debug, and anything more verbose is trace.
+func logrToZlogLevel(level int) zerolog.Level {
+	if level < 0 {
+		level = 0
+	}
+	return castToZlogLevel(int(zerolog.InfoLevel) - level)
+}
+
+func (s *LogrSink) Init(info LogrRuntimeInfo) {
+	s.callDepth = info.CallDepth
+}
+
+func (s *LogrSink) Enabled(level int) bool {
+	c := s.l.config()
+	return level <= c.verbosity && c.enabled(logrToZlogLevel(level))
+}
+
+func (s *LogrSink) Info(level int, msg string, keysAndValues ...any) {
+	c := s.l.config()
+	if level > c.verbosity {
+		return
+	}
+	e := c.event(logrToZlogLevel(level))
+	if c.reportCaller {
+		e = c.withCaller(e, s.callDepth+1)
+	}
+	e.Fields(normalizeKeyvals(keysAndValues)).Msg(msg)
+}
+
+func (s *LogrSink) Error(err error, msg string, keysAndValues ...any) {
+	c := s.l.config()
+	e := c.event(zerolog.ErrorLevel)
+	if c.reportCaller {
+		e = c.withCaller(e, s.callDepth+1)
+	}
+	e.Err(err).Fields(normalizeKeyvals(keysAndValues)).Msg(msg)
+}
+
+func (s *LogrSink) WithValues(keysAndValues ...any) LogrCompatSink {
+	nl := s.l.Child()
+	nl.update(func(c *config) {
+		c.base = c.base.With().Fields(normalizeKeyvals(keysAndValues)).Logger()
+	})
+	return &LogrSink{l: nl, callDepth: s.callDepth}
+}
+
+func (s *LogrSink) WithName(name string) LogrCompatSink {
+	nl := s.l.Child()
+	if prefix := nl.Prefix(); prefix != "" {
+		name = prefix + "/" + name
+	}
+	nl.SetPrefix(name)
+	return &LogrSink{l: nl, callDepth: s.callDepth}
+}
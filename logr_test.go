@@ -0,0 +1,53 @@
+package zwrap
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/rs/zerolog"
+)
+
+func TestLogrSink(t *testing.T) {
+	buf := new(bytes.Buffer)
+	zl := Wrap(zerolog.New(buf).Level(zerolog.DebugLevel))
+	zl.SetPrefix("parent")
+
+	var sink LogrCompatSink = NewLogrSink(zl)
+	sink.Init(LogrRuntimeInfo{CallDepth: 1})
+
+	if sink.Enabled(1) || zl.V(1) {
+		t.Error("expected V(1) to be disabled at the default verbosity")
+	}
+	zl.SetVerbosity(2)
+	if !sink.Enabled(0) || !sink.Enabled(1) {
+		t.Error("expected V(0) and V(1) to be enabled at debug level")
+	}
+	if sink.Enabled(2) {
+		t.Error("expected V(2) to be disabled at debug level")
+	}
+
+	child := sink.WithName("controller").WithName("reconciler").WithValues("kind", "Pod", "n", 3)
+	child.Info(1, "reconciling", "name", "yeet")
+	child.Info(2, "too verbose")
+	child.Error(errors.New("boom"), "failed")
+	sink.Info(0, "parent")
+
+	ms := decodeLines(t, buf)
+	if len(ms) != 3 {
+		t.Fatalf("expected 3 lines, got %d: %s", len(ms), buf.String())
+	}
+	if ms[0]["level"] != "debug" || ms[0]["caller"] != "parent/controller/reconciler" ||
+		ms[0]["kind"] != "Pod" || ms[0]["n"] != float64(3) || ms[0]["name"] != "yeet" {
+		t.Errorf("unexpected record: %v", ms[0])
+	}
+	if ms[1]["level"] != "error" || ms[1]["error"] != "boom" || ms[1]["message"] != "failed" {
+		t.Errorf("unexpected record: %v", ms[1])
+	}
+	if ms[2]["caller"] != "parent" || ms[2]["kind"] != nil {
+		t.Errorf("WithName/WithValues leaked into the parent: %v", ms[2])
+	}
+	if zl.Prefix() != "parent" {
+		t.Errorf("parent prefix changed to %q", zl.Prefix())
+	}
+}
@@ -0,0 +1,134 @@
+package zwrap
+
+import (
+	"github.com/rs/zerolog"
+)
+
+// LogrusFields mirrors logrus.Fields.
+type LogrusFields map[string]interface{}
+
+// LogrusLevel mirrors logrus.Level, which numbers levels from panic (0) to trace (6).
+type LogrusLevel uint32
+
+const (
+	LogrusPanicLevel LogrusLevel = iota
+	LogrusFatalLevel
+	LogrusErrorLevel
+	LogrusWarnLevel
+	LogrusInfoLevel
+	LogrusDebugLevel
+	LogrusTraceLevel
+)
+
+// LogrusStdLogger is an interface that provides compatibility with logrus.StdLogger.
+type LogrusStdLogger interface {
+	Print(...interface{})
+	Printf(string, ...interface{})
+	Println(...interface{})
+
+	Fatal(...interface{})
+	Fatalf(string, ...interface{})
+	Fatalln(...interface{})
+
+	Panic(...interface{})
+	Panicf(string, ...interface{})
+	Panicln(...interface{})
+}
+
+// LogrusFieldLogger mirrors the method set of logrus.FieldLogger without importing logrus.
+// The With* methods return LogrusFieldLogger instead of *logrus.Entry.
+type LogrusFieldLogger interface {
+	WithField(key string, value interface{}) LogrusFieldLogger
+	WithFields(fields LogrusFields) LogrusFieldLogger
+	WithError(err error) LogrusFieldLogger
+
+	Debugf(format string, args ...interface{})
+	Infof(format string, args ...interface{})
+	Printf(format string, args ...interface{})
+	Warnf(format string, args ...interface{})
+	Warningf(format string, args ...interface{})
+	Errorf(format string, args ...interface{})
+	Fatalf(format string, args ...interface{})
+	Panicf(format string, args ...interface{})
+
+	Debug(args ...interface{})
+	Info(args ...interface{})
+	Print(args ...interface{})
+	Warn(args ...interface{})
+	Warning(args ...interface{})
+	Error(args ...interface{})
+	Fatal(args ...interface{})
+	Panic(args ...interface{})
+
+	Debugln(args ...interface{})
+	Infoln(args ...interface{})
+	Println(args ...interface{})
+	Warnln(args ...interface{})
+	Warningln(args ...interface{})
+	Errorln(args ...interface{})
+	Fatalln(args ...interface{})
+	Panicln(args ...interface{})
+}
+
+var (
+	_ LogrusStdLogger   = &Logger{}
+	_ LogrusFieldLogger = (*LogrusEntry)(nil)
+)
+
+// LogrusEntry adapts a Logger to logrus.FieldLogger. Unlike Logger.WithFields, its With* methods
+// always return a new entry backed by a Child, leaving the receiver untouched.
+type LogrusEntry struct {
+	*Logger
+}
+
+// NewLogrusEntry returns a LogrusEntry writing through l.
+func NewLogrusEntry(l *Logger) *LogrusEntry {
+	return &LogrusEntry{Logger: l}
+}
+
+func (e *LogrusEntry) with(fn func(zerolog.Context) zerolog.Context) *LogrusEntry {
+	nl := e.Logger.Child()
+	nl.update(func(c *config) {
+		c.base = fn(c.base.With()).Logger()
+	})
+	return &LogrusEntry{Logger: nl}
+}
+
+func (e *LogrusEntry) WithField(key string, value interface{}) LogrusFieldLogger {
+	return e.with(func(zc zerolog.Context) zerolog.Context {
+		return zc.Fields([]interface{}{key, value})
+	})
+}
+
+func (e *LogrusEntry) WithFields(fields LogrusFields) LogrusFieldLogger {
+	return e.with(func(zc zerolog.Context) zerolog.Context {
+		return zc.Fields(map[string]interface{}(fields))
+	})
+}
+
+func (e *LogrusEntry) WithError(err error) LogrusFieldLogger {
+	return e.with(func(zc zerolog.Context) zerolog.Context {
+		return zc.AnErr(zerolog.ErrorFieldName, err)
+	})
+}
+
+// SetLevel sets the level of the underlying Logger using logrus' numbering.
+func (e *LogrusEntry) SetLevel(level LogrusLevel) {
+	e.Logger.SetLevel(uint32(level))
+}
+
+func (e *LogrusEntry) GetLevel() LogrusLevel {
+	return zlogToLogrusLevel(e.Logger.ZLogger().GetLevel())
+}
+
+// zlogToLogrusLevel is the inverse of the uint32 case of toZlogLevel.
+func zlogToLogrusLevel(level zerolog.Level) LogrusLevel {
+	switch {
+	case level <= zerolog.TraceLevel:
+		return LogrusTraceLevel
+	case level >= zerolog.PanicLevel:
+		return LogrusPanicLevel
+	default:
+		return LogrusDebugLevel - LogrusLevel(level)
+	}
+}
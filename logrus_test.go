@@ -0,0 +1,46 @@
+package zwrap
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/rs/zerolog"
+)
+
+func TestLogrusLevels(t *testing.T) {
+	zl := Wrap(zerolog.Nop())
+	entry := NewLogrusEntry(zl)
+	for level := LogrusPanicLevel; level <= LogrusTraceLevel; level++ {
+		entry.SetLevel(level)
+		if got := entry.GetLevel(); got != level {
+			t.Errorf("SetLevel(%d) then GetLevel() = %d", level, got)
+		}
+	}
+	entry.SetLevel(LogrusWarnLevel)
+	if zl.ZLogger().GetLevel() != zerolog.WarnLevel {
+		t.Errorf("expected LogrusWarnLevel to map to zerolog.WarnLevel, got %v", zl.ZLogger().GetLevel())
+	}
+}
+
+func TestLogrusEntry(t *testing.T) {
+	buf := new(bytes.Buffer)
+	zl := Wrap(zerolog.New(buf))
+	var fl LogrusFieldLogger = NewLogrusEntry(zl)
+
+	scoped := fl.WithField("request", "abc").WithFields(LogrusFields{"user": "kayos"}).WithError(errors.New("boom"))
+	scoped.Warningf("failed %d times", 2)
+	fl.Info("unscoped")
+
+	ms := decodeLines(t, buf)
+	if len(ms) != 2 {
+		t.Fatalf("expected 2 lines, got %d: %s", len(ms), buf.String())
+	}
+	if ms[0]["level"] != "warn" || ms[0]["request"] != "abc" || ms[0]["user"] != "kayos" ||
+		ms[0]["error"] != "boom" || ms[0]["message"] != "failed 2 times" {
+		t.Errorf("unexpected record: %v", ms[0])
+	}
+	if ms[1]["request"] != nil || ms[1]["error"] != nil {
+		t.Errorf("fields leaked into the parent entry: %v", ms[1])
+	}
+}
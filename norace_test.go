@@ -0,0 +1,5 @@
+//go:build !race
+
+package zwrap
+
+const raceEnabled = false
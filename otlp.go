@@ -0,0 +1,357 @@
+package zwrap
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+// OTLPOptions configure an OTLPWriter.
+type OTLPOptions struct {
+	// Endpoint is the full URL of the collector's OTLP/HTTP logs endpoint, e.g. http://localhost:4318/v1/logs.
+	Endpoint string
+	// Headers are added to every export request, e.g. for authentication.
+	Headers map[string]string
+	// Resource holds the resource attributes, such as service.name, sent with every batch.
+	Resource map[string]interface{}
+	// ScopeName names the instrumentation scope, the zwrap package path if empty.
+	ScopeName string
+	// BatchSize is the number of records that triggers an export, 512 if zero.
+	BatchSize int
+	// FlushInterval is how often pending records are exported, 5 seconds if zero. Negative disables it.
+	FlushInterval time.Duration
+	// Client sends the export requests, a client with a 10 second timeout if nil.
+	Client *http.Client
+}
+
+// OTLPWriter is an io.Writer that converts zerolog JSON events into OTLP log records and exports them
+// in batches as OTLP/HTTP JSON. Write only queues events: they are exported in the background once BatchSize
+// of them are pending and every FlushInterval, and directly on Flush and Close. A failed export drops its batch;
+// the error of a background export is returned by the next Flush or Close.
+//
+// The level becomes the severity, the message the body, the fields written by ReportTrace the trace context,
+// and every other field an attribute.
+type OTLPWriter struct {
+	opts     OTLPOptions
+	resource []otlpKeyValue
+
+	mu      sync.Mutex
+	pending []otlpLogRecord
+	// err is the error of the last failed background export.
+	err error
+
+	// full signals the flush loop that a batch is ready.
+	full      chan struct{}
+	done      chan struct{}
+	stopped   chan struct{}
+	closeOnce sync.Once
+}
+
+// NewOTLPWriter returns an OTLPWriter exporting to opts.Endpoint. Close it to stop its flush loop
+// and export what is left.
+func NewOTLPWriter(opts OTLPOptions) *OTLPWriter {
+	if opts.ScopeName == "" {
+		opts.ScopeName = pkgPath
+	}
+	if opts.BatchSize <= 0 {
+		opts.BatchSize = 512
+	}
+	if opts.FlushInterval == 0 {
+		opts.FlushInterval = 5 * time.Second
+	}
+	if opts.Client == nil {
+		opts.Client = &http.Client{Timeout: 10 * time.Second}
+	}
+	w := &OTLPWriter{
+		opts:     opts,
+		resource: otlpAttributes(opts.Resource),
+		full:     make(chan struct{}, 1),
+		done:     make(chan struct{}),
+		stopped:  make(chan struct{}),
+	}
+	go w.flushLoop()
+	return w
+}
+
+func (w *OTLPWriter) flushLoop() {
+	defer close(w.stopped)
+	var tick <-chan time.Time
+	if w.opts.FlushInterval > 0 {
+		t := time.NewTicker(w.opts.FlushInterval)
+		defer t.Stop()
+		tick = t.C
+	}
+	for {
+		select {
+		case <-tick:
+		case <-w.full:
+		case <-w.done:
+			return
+		}
+		if err := w.flush(); err != nil {
+			w.mu.Lock()
+			w.err = err
+			w.mu.Unlock()
+		}
+	}
+}
+
+func (w *OTLPWriter) Write(p []byte) (n int, err error) {
+	err = splitLines(p, func(line []byte) error {
+		r, err := parseRecord(line)
+		if err != nil {
+			return err
+		}
+		w.mu.Lock()
+		w.pending = append(w.pending, otlpRecord(r))
+		full := len(w.pending) >= w.opts.BatchSize
+		w.mu.Unlock()
+		if full {
+			select {
+			case w.full <- struct{}{}:
+			default:
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// Flush exports all pending records. It returns the error of its own export or, failing that,
+// of the last failed background export.
+func (w *OTLPWriter) Flush() error {
+	err := w.flush()
+	w.mu.Lock()
+	if err == nil {
+		err = w.err
+	}
+	w.err = nil
+	w.mu.Unlock()
+	return err
+}
+
+func (w *OTLPWriter) flush() error {
+	w.mu.Lock()
+	batch := w.pending
+	w.pending = nil
+	w.mu.Unlock()
+	if len(batch) == 0 {
+		return nil
+	}
+	return w.export(batch)
+}
+
+// Close stops the flush loop, waiting for a background export in progress, and exports all pending records.
+func (w *OTLPWriter) Close() error {
+	w.closeOnce.Do(func() {
+		close(w.done)
+		<-w.stopped
+	})
+	return w.Flush()
+}
+
+func (w *OTLPWriter) export(batch []otlpLogRecord) error {
+	body, err := json.Marshal(otlpLogsData{ResourceLogs: []otlpResourceLogs{{
+		Resource:  otlpResource{Attributes: w.resource},
+		ScopeLogs: []otlpScopeLogs{{Scope: otlpScope{Name: w.opts.ScopeName}, LogRecords: batch}},
+	}}})
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequest(http.MethodPost, w.opts.Endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range w.opts.Headers {
+		req.Header.Set(k, v)
+	}
+	resp, err := w.opts.Client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	msg, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("otlp export: %s: %s", resp.Status, bytes.TrimSpace(msg))
+	}
+	return nil
+}
+
+// The types below follow the JSON encoding of the OTLP logs protobuf messages, in which 64 bit integers are strings.
+
+type otlpLogsData struct {
+	ResourceLogs []otlpResourceLogs `json:"resourceLogs"`
+}
+
+type otlpResourceLogs struct {
+	Resource  otlpResource    `json:"resource"`
+	ScopeLogs []otlpScopeLogs `json:"scopeLogs"`
+}
+
+type otlpResource struct {
+	Attributes []otlpKeyValue `json:"attributes,omitempty"`
+}
+
+type otlpScopeLogs struct {
+	Scope      otlpScope       `json:"scope"`
+	LogRecords []otlpLogRecord `json:"logRecords"`
+}
+
+type otlpScope struct {
+	Name string `json:"name"`
+}
+
+type otlpLogRecord struct {
+	TimeUnixNano         string         `json:"timeUnixNano,omitempty"`
+	ObservedTimeUnixNano string         `json:"observedTimeUnixNano"`
+	SeverityNumber       int            `json:"severityNumber,omitempty"`
+	SeverityText         string         `json:"severityText,omitempty"`
+	Body                 *otlpAnyValue  `json:"body,omitempty"`
+	Attributes           []otlpKeyValue `json:"attributes,omitempty"`
+	TraceID              string         `json:"traceId,omitempty"`
+	SpanID               string         `json:"spanId,omitempty"`
+	Flags                uint32         `json:"flags,omitempty"`
+}
+
+type otlpKeyValue struct {
+	Key   string       `json:"key"`
+	Value otlpAnyValue `json:"value"`
+}
+
+type otlpAnyValue struct {
+	StringValue *string         `json:"stringValue,omitempty"`
+	BoolValue   *bool           `json:"boolValue,omitempty"`
+	IntValue    string          `json:"intValue,omitempty"`
+	DoubleValue *float64        `json:"doubleValue,omitempty"`
+	ArrayValue  *otlpArrayValue `json:"arrayValue,omitempty"`
+	KvlistValue *otlpKvlist     `json:"kvlistValue,omitempty"`
+}
+
+type otlpArrayValue struct {
+	Values []otlpAnyValue `json:"values"`
+}
+
+type otlpKvlist struct {
+	Values []otlpKeyValue `json:"values"`
+}
+
+// otlpSeverity maps zerolog levels onto the first severity number of the matching OTel range.
+func otlpSeverity(level zerolog.Level) int {
+	switch level {
+	case zerolog.TraceLevel:
+		return 1
+	case zerolog.DebugLevel:
+		return 5
+	case zerolog.InfoLevel:
+		return 9
+	case zerolog.WarnLevel:
+		return 13
+	case zerolog.ErrorLevel:
+		return 17
+	case zerolog.FatalLevel:
+		return 21
+	case zerolog.PanicLevel:
+		return 24
+	default:
+		return 0
+	}
+}
+
+func otlpRecord(r record) otlpLogRecord {
+	lr := otlpLogRecord{
+		ObservedTimeUnixNano: strconv.FormatInt(time.Now().UnixNano(), 10),
+		SeverityNumber:       otlpSeverity(r.level),
+	}
+	if !r.time.IsZero() {
+		lr.TimeUnixNano = strconv.FormatInt(r.time.UnixNano(), 10)
+	}
+	if r.level != zerolog.NoLevel {
+		lr.SeverityText = r.level.String()
+	}
+	if r.msg != "" {
+		lr.Body = &otlpAnyValue{StringValue: &r.msg}
+	}
+	// collectors reject the whole request over a malformed ID, so anything else stays an attribute.
+	if v, ok := r.fields[TraceIDFieldName].(string); ok && len(v) == 32 && isLowerHex(v) {
+		lr.TraceID = v
+		delete(r.fields, TraceIDFieldName)
+	}
+	if v, ok := r.fields[SpanIDFieldName].(string); ok && len(v) == 16 && isLowerHex(v) {
+		lr.SpanID = v
+		delete(r.fields, SpanIDFieldName)
+	}
+	if v, ok := r.fields[TraceFlagsFieldName].(string); ok {
+		if flags, err := strconv.ParseUint(v, 16, 8); err == nil {
+			lr.Flags = uint32(flags)
+			delete(r.fields, TraceFlagsFieldName)
+		}
+	}
+	lr.Attributes = otlpAttributes(r.fields)
+	return lr
+}
+
+func otlpAttributes(m map[string]interface{}) []otlpKeyValue {
+	if len(m) == 0 {
+		return nil
+	}
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	kvs := make([]otlpKeyValue, 0, len(keys))
+	for _, k := range keys {
+		kvs = append(kvs, otlpKeyValue{Key: k, Value: otlpValue(m[k])})
+	}
+	return kvs
+}
+
+func otlpValue(v interface{}) otlpAnyValue {
+	switch v := v.(type) {
+	case string:
+		return otlpAnyValue{StringValue: &v}
+	case bool:
+		return otlpAnyValue{BoolValue: &v}
+	case json.Number:
+		if _, err := v.Int64(); err == nil {
+			return otlpAnyValue{IntValue: v.String()}
+		}
+		f, _ := v.Float64()
+		return otlpAnyValue{DoubleValue: &f}
+	case int:
+		return otlpAnyValue{IntValue: strconv.Itoa(v)}
+	case int64:
+		return otlpAnyValue{IntValue: strconv.FormatInt(v, 10)}
+	case float64:
+		return otlpAnyValue{DoubleValue: &v}
+	case []interface{}:
+		values := make([]otlpAnyValue, 0, len(v))
+		for _, e := range v {
+			values = append(values, otlpValue(e))
+		}
+		return otlpAnyValue{ArrayValue: &otlpArrayValue{Values: values}}
+	case map[string]interface{}:
+		values := otlpAttributes(v)
+		if values == nil {
+			values = []otlpKeyValue{}
+		}
+		return otlpAnyValue{KvlistValue: &otlpKvlist{Values: values}}
+	case nil:
+		return otlpAnyValue{}
+	default:
+		s := fmt.Sprint(v)
+		return otlpAnyValue{StringValue: &s}
+	}
+}
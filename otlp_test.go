@@ -0,0 +1,167 @@
+package zwrap
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+func TestOTLPWriter(t *testing.T) {
+	var (
+		mu       sync.Mutex
+		requests []otlpLogsData
+		exported = make(chan struct{}, 2)
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/logs" || r.Header.Get("Content-Type") != "application/json" ||
+			r.Header.Get("Authorization") != "Bearer token" {
+			t.Errorf("unexpected request: %s %s %v", r.Method, r.URL, r.Header)
+		}
+		var data otlpLogsData
+		if err := json.NewDecoder(r.Body).Decode(&data); err != nil {
+			t.Errorf("failed to decode export request: %v", err)
+		}
+		mu.Lock()
+		requests = append(requests, data)
+		mu.Unlock()
+		exported <- struct{}{}
+	}))
+	defer srv.Close()
+
+	w := NewOTLPWriter(OTLPOptions{
+		Endpoint:      srv.URL + "/v1/logs",
+		Headers:       map[string]string{"Authorization": "Bearer token"},
+		Resource:      map[string]interface{}{"service.name": "zwrap-test"},
+		BatchSize:     2,
+		FlushInterval: -1,
+	})
+	zl := Wrap(zerolog.New(w).With().Timestamp().Logger()).WithReportTrace()
+	ctx := ContextWithTraceparent(context.Background(), "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+
+	zl.WithFields(map[string]interface{}{"count": 3, "ratio": 0.5, "ok": true,
+		"tags": []string{"a"}, "nested": map[string]interface{}{"k": "v"}}).InfoContext(ctx, "hello")
+	zl.Error("failed")
+	select {
+	case <-exported:
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected an export once the batch was full")
+	}
+	zl.Warn("pending")
+
+	mu.Lock()
+	if len(requests) != 1 {
+		t.Fatalf("expected one export once the batch was full, got %d", len(requests))
+	}
+	mu.Unlock()
+	if err := w.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+	if len(requests) != 2 {
+		t.Fatalf("expected Close to export the pending record, got %d requests", len(requests))
+	}
+
+	rl := requests[0].ResourceLogs[0]
+	if a := rl.Resource.Attributes; len(a) != 1 || a[0].Key != "service.name" || *a[0].Value.StringValue != "zwrap-test" {
+		t.Errorf("unexpected resource attributes: %+v", a)
+	}
+	records := rl.ScopeLogs[0].LogRecords
+	if len(records) != 2 {
+		t.Fatalf("expected 2 records in the first batch, got %d", len(records))
+	}
+	r := records[0]
+	if r.SeverityNumber != 9 || r.SeverityText != "info" || *r.Body.StringValue != "hello" || r.TimeUnixNano == "" {
+		t.Errorf("unexpected record: %+v", r)
+	}
+	if r.TraceID != "4bf92f3577b34da6a3ce929d0e0e4736" || r.SpanID != "00f067aa0ba902b7" || r.Flags != 1 {
+		t.Errorf("unexpected trace context: %+v", r)
+	}
+	attrs := map[string]otlpAnyValue{}
+	for _, kv := range r.Attributes {
+		attrs[kv.Key] = kv.Value
+	}
+	if len(attrs) != 5 || attrs["count"].IntValue != "3" || *attrs["ratio"].DoubleValue != 0.5 || !*attrs["ok"].BoolValue ||
+		*attrs["tags"].ArrayValue.Values[0].StringValue != "a" || attrs["nested"].KvlistValue.Values[0].Key != "k" {
+		t.Errorf("unexpected attributes: %+v", r.Attributes)
+	}
+	if records[1].SeverityNumber != 17 {
+		t.Errorf("expected error severity, got %+v", records[1])
+	}
+	if r := requests[1].ResourceLogs[0].ScopeLogs[0].LogRecords[0]; r.SeverityNumber != 13 {
+		t.Errorf("expected warn severity, got %+v", r)
+	}
+}
+
+func TestOTLPWriter_ExportError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "overloaded", http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	w := NewOTLPWriter(OTLPOptions{Endpoint: srv.URL, BatchSize: 1, FlushInterval: time.Hour})
+	if _, err := w.Write([]byte(`{"level":"info","message":"hello"}` + "\n")); err != nil {
+		t.Errorf("expected Write to only queue the record, got %v", err)
+	}
+	if _, err := w.Write([]byte("not json\n")); err == nil {
+		t.Errorf("expected a decode error")
+	}
+	if err := w.Close(); err == nil {
+		t.Errorf("expected the export error to be returned by Close")
+	}
+}
+
+func TestOTLPWriter_HungCollector(t *testing.T) {
+	release := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+	}))
+	defer srv.Close()
+
+	w := NewOTLPWriter(OTLPOptions{Endpoint: srv.URL, BatchSize: 1, FlushInterval: -1})
+	defer w.Close()
+	defer close(release)
+	zl := Wrap(zerolog.New(w))
+	written := make(chan struct{})
+	go func() {
+		defer close(written)
+		for i := 0; i < 10; i++ {
+			zl.Infof("yeet %d", i)
+		}
+	}()
+	select {
+	case <-written:
+	case <-time.After(5 * time.Second):
+		t.Fatal("logging blocked on a hung collector")
+	}
+}
+
+func TestOTLPRecord_InvalidTraceContext(t *testing.T) {
+	tests := []struct {
+		name   string
+		fields map[string]interface{}
+	}{
+		{"not hex", map[string]interface{}{"trace_id": "req-42", "span_id": "span-1"}},
+		{"wrong length", map[string]interface{}{"trace_id": "4bf92f35", "span_id": "00f067aa0ba902b700"}},
+		{"uppercase", map[string]interface{}{"trace_id": "4BF92F3577B34DA6A3CE929D0E0E4736", "span_id": "00F067AA0BA902B7"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lr := otlpRecord(record{level: zerolog.InfoLevel, fields: tt.fields})
+			if lr.TraceID != "" || lr.SpanID != "" {
+				t.Errorf("expected invalid IDs not to be promoted, got %q %q", lr.TraceID, lr.SpanID)
+			}
+			attrs := map[string]string{}
+			for _, kv := range lr.Attributes {
+				attrs[kv.Key] = *kv.Value.StringValue
+			}
+			if attrs["trace_id"] != tt.fields["trace_id"] || attrs["span_id"] != tt.fields["span_id"] {
+				t.Errorf("expected invalid IDs to be kept as attributes, got %v", attrs)
+			}
+		})
+	}
+}
@@ -0,0 +1,145 @@
+package zwrap
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+// PgxLogLevel mirrors github.com/jackc/pgx/v5/tracelog.LogLevel.
+type PgxLogLevel int
+
+const (
+	PgxLogLevelNone PgxLogLevel = iota + 1
+	PgxLogLevelError
+	PgxLogLevelWarn
+	PgxLogLevelInfo
+	PgxLogLevelDebug
+	PgxLogLevelTrace
+)
+
+// PgxRedacted replaces SQL arguments redacted by PgxLogger.
+const PgxRedacted = "[REDACTED]"
+
+// Field names used by PgxLogger. pgx's "time" entry holds the query duration and is renamed to
+// PgxElapsedFieldName so it does not clash with zerolog's timestamp.
+var (
+	PgxTraceIDFieldName = "trace_id"
+	PgxElapsedFieldName = "elapsed"
+)
+
+// PgxOptions configure a PgxLogger.
+type PgxOptions struct {
+	// RedactArgs replaces every value of the "args" entry with PgxRedacted.
+	RedactArgs bool
+	// RedactArg, if set, is called for each value of the "args" entry and logs what it returns instead.
+	// It takes precedence over RedactArgs.
+	RedactArg func(i int, arg any) any
+	// TraceID, if set, returns the trace ID to log for the context passed to Log.
+	TraceID func(ctx context.Context) (string, bool)
+}
+
+// PgxCompatLogger mirrors pgx's tracelog.Logger. As with LogrCompatSink, it refers to pgx's own level type
+// and needs a small shim in the importing package.
+type PgxCompatLogger interface {
+	Log(ctx context.Context, level PgxLogLevel, msg string, data map[string]any)
+}
+
+var _ PgxCompatLogger = (*PgxLogger)(nil)
+
+// PgxLogger adapts a Logger to pgx's tracelog.Logger, and through pgx's stdlib driver to database/sql.
+type PgxLogger struct {
+	l    *Logger
+	opts PgxOptions
+}
+
+// NewPgxLogger returns a PgxLogger writing through l.
+func NewPgxLogger(l *Logger, opts PgxOptions) *PgxLogger {
+	return &PgxLogger{l: l, opts: opts}
+}
+
+// pgxToZlogLevel maps pgx levels onto zerolog levels, pgx's Trace through Error are zerolog's mirrored around 2.
+func pgxToZlogLevel(level PgxLogLevel) zerolog.Level {
+	if level <= PgxLogLevelNone {
+		return zerolog.Disabled
+	}
+	return castToZlogLevel(5 - int(level))
+}
+
+// Log writes msg with the entries of data as fields, in key order. Nested maps become nested objects,
+// "err" is written to zerolog.ErrorFieldName and durations honour zerolog's duration settings.
+func (p *PgxLogger) Log(ctx context.Context, level PgxLogLevel, msg string, data map[string]any) {
+	if level <= PgxLogLevelNone {
+		return
+	}
+	c := p.l.config()
+	e := c.withAutoCaller(c.event(pgxToZlogLevel(level)).Ctx(ctx))
+	if e == nil {
+		return
+	}
+	if p.opts.TraceID != nil {
+		if id, ok := p.opts.TraceID(ctx); ok {
+			e.Str(PgxTraceIDFieldName, id)
+		}
+	}
+	for _, k := range sortedKeys(data) {
+		v := data[k]
+		switch {
+		case k == "err":
+			if err, ok := v.(error); ok {
+				e.AnErr(zerolog.ErrorFieldName, err)
+				continue
+			}
+		case k == "args":
+			v = p.redact(v)
+		case k == "time":
+			k = PgxElapsedFieldName
+		}
+		appendPgxField(e, k, v)
+	}
+	e.Msg(msg)
+}
+
+func (p *PgxLogger) redact(v any) any {
+	args, ok := v.([]any)
+	if !ok || (p.opts.RedactArg == nil && !p.opts.RedactArgs) {
+		return v
+	}
+	redacted := make([]any, len(args))
+	for i, arg := range args {
+		if p.opts.RedactArg != nil {
+			redacted[i] = p.opts.RedactArg(i, arg)
+			continue
+		}
+		redacted[i] = PgxRedacted
+	}
+	return redacted
+}
+
+func sortedKeys(m map[string]any) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func appendPgxField(e *zerolog.Event, key string, v any) {
+	switch val := v.(type) {
+	case map[string]any:
+		d := zerolog.Dict()
+		for _, k := range sortedKeys(val) {
+			appendPgxField(d, k, val[k])
+		}
+		e.Dict(key, d)
+	case time.Duration:
+		e.Dur(key, val)
+	case error:
+		e.AnErr(key, val)
+	default:
+		e.Interface(key, val)
+	}
+}
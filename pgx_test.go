@@ -0,0 +1,74 @@
+package zwrap
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+func TestPgxToZlogLevel(t *testing.T) {
+	tests := []struct {
+		in   PgxLogLevel
+		want zerolog.Level
+	}{
+		{PgxLogLevelNone, zerolog.Disabled},
+		{PgxLogLevelError, zerolog.ErrorLevel},
+		{PgxLogLevelWarn, zerolog.WarnLevel},
+		{PgxLogLevelInfo, zerolog.InfoLevel},
+		{PgxLogLevelDebug, zerolog.DebugLevel},
+		{PgxLogLevelTrace, zerolog.TraceLevel},
+	}
+	for _, tt := range tests {
+		if got := pgxToZlogLevel(tt.in); got != tt.want {
+			t.Errorf("pgxToZlogLevel(%d) = %v, want %v", tt.in, got, tt.want)
+		}
+	}
+}
+
+type pgxTraceKey struct{}
+
+func TestPgxLogger_Log(t *testing.T) {
+	buf := new(bytes.Buffer)
+	zl := Wrap(zerolog.New(buf).Level(zerolog.TraceLevel))
+	ctx := context.WithValue(context.Background(), pgxTraceKey{}, "abc123")
+	traceID := func(ctx context.Context) (string, bool) {
+		id, ok := ctx.Value(pgxTraceKey{}).(string)
+		return id, ok
+	}
+	data := map[string]any{
+		"sql":  "SELECT * FROM users WHERE email = $1",
+		"args": []any{"kayos@example.com"},
+		"time": 1500 * time.Microsecond,
+		"err":  errors.New("timeout"),
+		"conn": map[string]any{"pid": 42},
+	}
+
+	NewPgxLogger(zl, PgxOptions{RedactArgs: true, TraceID: traceID}).Log(ctx, PgxLogLevelError, "Query", data)
+	NewPgxLogger(zl, PgxOptions{}).Log(context.Background(), PgxLogLevelTrace, "Query", data)
+	NewPgxLogger(zl, PgxOptions{}).Log(context.Background(), PgxLogLevelNone, "dropped", data)
+
+	ms := decodeLines(t, buf)
+	if len(ms) != 2 {
+		t.Fatalf("expected 2 lines, got %d: %s", len(ms), buf.String())
+	}
+	m := ms[0]
+	if m["level"] != "error" || m["trace_id"] != "abc123" || m["error"] != "timeout" || m["elapsed"] != 1.5 {
+		t.Errorf("unexpected record: %v", m)
+	}
+	if args, ok := m["args"].([]interface{}); !ok || len(args) != 1 || args[0] != PgxRedacted {
+		t.Errorf("expected redacted args, got %v", m["args"])
+	}
+	if conn, ok := m["conn"].(map[string]interface{}); !ok || conn["pid"] != float64(42) {
+		t.Errorf("expected nested conn object, got %v", m["conn"])
+	}
+	if ms[1]["level"] != "trace" || ms[1]["trace_id"] != nil {
+		t.Errorf("unexpected record: %v", ms[1])
+	}
+	if args, ok := ms[1]["args"].([]interface{}); !ok || args[0] != "kayos@example.com" {
+		t.Errorf("expected args to be kept, got %v", ms[1]["args"])
+	}
+}
@@ -0,0 +1,6 @@
+//go:build race
+
+package zwrap
+
+// the race detector makes some otherwise stack allocated values escape.
+const raceEnabled = true
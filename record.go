@@ -0,0 +1,94 @@
+package zwrap
+
+import (
+	"bytes"
+	"encoding/json"
+	"strconv"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+// record is a zerolog JSON event decoded by the writers that forward events to other log formats.
+type record struct {
+	time  time.Time
+	level zerolog.Level
+	msg   string
+	// fields holds everything but the time, level and message, numbers are json.Number.
+	fields map[string]interface{}
+}
+
+// parseRecord decodes one zerolog JSON event. The time, level and message are taken from the fields named by
+// zerolog.TimestampFieldName, zerolog.LevelFieldName and zerolog.MessageFieldName; a missing level is
+// zerolog.NoLevel and a missing or unparseable time is left zero.
+func parseRecord(p []byte) (record, error) {
+	d := json.NewDecoder(bytes.NewReader(p))
+	d.UseNumber()
+	var fields map[string]interface{}
+	if err := d.Decode(&fields); err != nil {
+		return record{}, err
+	}
+
+	r := record{level: zerolog.NoLevel, fields: fields}
+	if v, ok := fields[zerolog.LevelFieldName].(string); ok {
+		if level, err := zerolog.ParseLevel(v); err == nil {
+			r.level = level
+			delete(fields, zerolog.LevelFieldName)
+		}
+	}
+	if v, ok := fields[zerolog.MessageFieldName].(string); ok {
+		r.msg = v
+		delete(fields, zerolog.MessageFieldName)
+	}
+	if t, ok := parseRecordTime(fields[zerolog.TimestampFieldName]); ok {
+		r.time = t
+		delete(fields, zerolog.TimestampFieldName)
+	}
+	return r, nil
+}
+
+// parseRecordTime undoes zerolog's encoding of timestamps according to zerolog.TimeFieldFormat.
+func parseRecordTime(v interface{}) (time.Time, bool) {
+	switch v := v.(type) {
+	case string:
+		t, err := time.Parse(zerolog.TimeFieldFormat, v)
+		return t, err == nil
+	case json.Number:
+		if f, err := strconv.ParseFloat(v.String(), 64); err == nil && zerolog.TimeFieldFormat == zerolog.TimeFormatUnix {
+			sec := int64(f)
+			return time.Unix(sec, int64((f-float64(sec))*1e9)), true
+		}
+		n, err := v.Int64()
+		if err != nil {
+			return time.Time{}, false
+		}
+		switch zerolog.TimeFieldFormat {
+		case zerolog.TimeFormatUnixMs:
+			return time.UnixMilli(n), true
+		case zerolog.TimeFormatUnixMicro:
+			return time.UnixMicro(n), true
+		case zerolog.TimeFormatUnixNano:
+			return time.Unix(0, n), true
+		}
+	}
+	return time.Time{}, false
+}
+
+// splitLines calls fn for each non-empty line of p, so that writers accept several events per Write.
+func splitLines(p []byte, fn func(line []byte) error) error {
+	for len(p) > 0 {
+		line := p
+		if i := bytes.IndexByte(p, '\n'); i >= 0 {
+			line, p = p[:i], p[i+1:]
+		} else {
+			p = nil
+		}
+		if len(bytes.TrimSpace(line)) == 0 {
+			continue
+		}
+		if err := fn(line); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,38 @@
+package zwrap
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+func TestParseRecord(t *testing.T) {
+	saved := zerolog.TimeFieldFormat
+	defer func() { zerolog.TimeFieldFormat = saved }()
+
+	ts := time.Date(2024, 1, 2, 3, 4, 5, 6000000, time.UTC)
+	for _, format := range []string{time.RFC3339Nano, zerolog.TimeFormatUnixMs, zerolog.TimeFormatUnixMicro, zerolog.TimeFormatUnixNano} {
+		zerolog.TimeFieldFormat = format
+		buf := new(bytes.Buffer)
+		zl := zerolog.New(buf)
+		zl.Warn().Time(zerolog.TimestampFieldName, ts).Int("n", 1).Msg("hello")
+
+		r, err := parseRecord(buf.Bytes())
+		if err != nil {
+			t.Fatalf("%s: %v", format, err)
+		}
+		if !r.time.Equal(ts) || r.level != zerolog.WarnLevel || r.msg != "hello" {
+			t.Errorf("%s: unexpected record: %+v", format, r)
+		}
+		if len(r.fields) != 1 || r.fields["n"] == nil {
+			t.Errorf("%s: expected only the n field to be left, got %v", format, r.fields)
+		}
+	}
+
+	r, err := parseRecord([]byte(`{"message":"no level"}`))
+	if err != nil || r.level != zerolog.NoLevel || !r.time.IsZero() {
+		t.Errorf("unexpected record: %+v, %v", r, err)
+	}
+}
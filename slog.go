@@ -0,0 +1,143 @@
+package zwrap
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/rs/zerolog"
+)
+
+// SlogHandler is a slog.Handler that writes through a wrapped Logger, so that one Logger
+// can serve both the zwrap methods and log/slog. The Logger's prefix, force level and
+// panic/fatal bypass settings apply to records handled by SlogHandler.
+type SlogHandler struct {
+	l    *Logger
+	goas []groupOrAttrs
+}
+
+// groupOrAttrs holds either a group name or a list of attributes, in the order
+// they were added with WithGroup and WithAttrs.
+type groupOrAttrs struct {
+	group string
+	attrs []slog.Attr
+}
+
+var _ slog.Handler = (*SlogHandler)(nil)
+
+// NewSlogHandler returns a slog.Handler backed by l.
+func NewSlogHandler(l *Logger) *SlogHandler {
+	return &SlogHandler{l: l}
+}
+
+// Slog returns a *slog.Logger backed by l.
+func (l *Logger) Slog() *slog.Logger {
+	return slog.New(NewSlogHandler(l))
+}
+
+func (h *SlogHandler) Enabled(_ context.Context, level slog.Level) bool {
+	return h.l.config().enabled(castToZlogLevel(level))
+}
+
+func (h *SlogHandler) Handle(ctx context.Context, r slog.Record) error {
+	c := h.l.config()
+	e := c.event(castToZlogLevel(r.Level)).Ctx(ctx)
+	if e == nil {
+		return nil
+	}
+
+	attrs := make([]slog.Attr, 0, r.NumAttrs())
+	r.Attrs(func(a slog.Attr) bool {
+		attrs = append(attrs, a)
+		return true
+	})
+
+	// walk backwards so that each group wraps everything added after it.
+	for i := len(h.goas) - 1; i >= 0; i-- {
+		goa := h.goas[i]
+		if goa.group == "" {
+			attrs = append(append(make([]slog.Attr, 0, len(goa.attrs)+len(attrs)), goa.attrs...), attrs...)
+			continue
+		}
+		if len(attrs) == 0 {
+			continue
+		}
+		attrs = []slog.Attr{{Key: goa.group, Value: slog.GroupValue(attrs...)}}
+	}
+
+	if c.reportCaller {
+		e = c.withCallerPC(e, r.PC)
+	}
+
+	for _, a := range attrs {
+		appendSlogAttr(e, a)
+	}
+
+	e.Msg(r.Message)
+	return nil
+}
+
+func (h *SlogHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	if len(attrs) == 0 {
+		return h
+	}
+	return h.withGroupOrAttrs(groupOrAttrs{attrs: attrs})
+}
+
+func (h *SlogHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	return h.withGroupOrAttrs(groupOrAttrs{group: name})
+}
+
+func (h *SlogHandler) withGroupOrAttrs(goa groupOrAttrs) *SlogHandler {
+	nh := &SlogHandler{l: h.l, goas: make([]groupOrAttrs, len(h.goas), len(h.goas)+1)}
+	copy(nh.goas, h.goas)
+	nh.goas = append(nh.goas, goa)
+	return nh
+}
+
+func appendSlogAttr(e *zerolog.Event, a slog.Attr) {
+	a.Value = a.Value.Resolve()
+	if a.Equal(slog.Attr{}) {
+		return
+	}
+	switch a.Value.Kind() {
+	case slog.KindGroup:
+		attrs := a.Value.Group()
+		if len(attrs) == 0 {
+			return
+		}
+		if a.Key == "" {
+			for _, ga := range attrs {
+				appendSlogAttr(e, ga)
+			}
+			return
+		}
+		d := zerolog.Dict()
+		for _, ga := range attrs {
+			appendSlogAttr(d, ga)
+		}
+		e.Dict(a.Key, d)
+	case slog.KindString:
+		e.Str(a.Key, a.Value.String())
+	case slog.KindInt64:
+		e.Int64(a.Key, a.Value.Int64())
+	case slog.KindUint64:
+		e.Uint64(a.Key, a.Value.Uint64())
+	case slog.KindFloat64:
+		e.Float64(a.Key, a.Value.Float64())
+	case slog.KindBool:
+		e.Bool(a.Key, a.Value.Bool())
+	case slog.KindDuration:
+		e.Dur(a.Key, a.Value.Duration())
+	case slog.KindTime:
+		e.Time(a.Key, a.Value.Time())
+	default:
+		if err, ok := a.Value.Any().(error); ok {
+			e.AnErr(a.Key, err)
+			return
+		}
+		e.Interface(a.Key, a.Value.Any())
+	}
+}
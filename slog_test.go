@@ -0,0 +1,64 @@
+package zwrap
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"testing"
+
+	"github.com/rs/zerolog"
+)
+
+func decodeLines(t *testing.T, buf *bytes.Buffer) []map[string]any {
+	t.Helper()
+	var ms []map[string]any
+	for _, line := range bytes.Split(buf.Bytes(), []byte{'\n'}) {
+		if len(line) == 0 {
+			continue
+		}
+		var m map[string]any
+		if err := json.Unmarshal(line, &m); err != nil {
+			t.Fatalf("failed to decode %q: %v", line, err)
+		}
+		ms = append(ms, m)
+	}
+	return ms
+}
+
+func TestSlogHandler(t *testing.T) {
+	buf := new(bytes.Buffer)
+	zl := Wrap(zerolog.New(buf))
+	zl.SetPrefix("slog")
+	sl := zl.Slog().With("a", 1).WithGroup("g").With("b", "two")
+
+	sl.Debug("nope")
+	sl.Warn("yeet", slog.Group("inner", slog.Bool("c", true)))
+
+	zl.ForceLevel(zerolog.ErrorLevel)
+	if !sl.Enabled(context.Background(), slog.LevelDebug) {
+		t.Error("expected debug to be enabled when error level is forced")
+	}
+	sl.Info("forced")
+
+	ms := decodeLines(t, buf)
+	if len(ms) != 3 {
+		t.Fatalf("expected 3 lines, got %d: %s", len(ms), buf.String())
+	}
+	if ms[0]["level"] != "debug" || ms[0]["message"] != "nope" {
+		t.Errorf("unexpected record: %v", ms[0])
+	}
+	if ms[1]["level"] != "warn" || ms[1]["caller"] != "slog" || ms[1]["a"] != float64(1) {
+		t.Errorf("unexpected record: %v", ms[1])
+	}
+	g, ok := ms[1]["g"].(map[string]any)
+	if !ok || g["b"] != "two" {
+		t.Fatalf("expected group g with b, got %v", ms[1])
+	}
+	if inner, ok := g["inner"].(map[string]any); !ok || inner["c"] != true {
+		t.Errorf("expected nested group inner, got %v", g)
+	}
+	if ms[2]["level"] != "error" {
+		t.Errorf("expected forced error level, got %v", ms[2]["level"])
+	}
+}
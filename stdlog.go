@@ -0,0 +1,124 @@
+package zwrap
+
+import (
+	"bytes"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Field names used for the parts of a standard library log line that have no zerolog counterpart.
+// The file:line added by log.Lshortfile and log.Llongfile goes to CallerFileFieldName.
+var (
+	StdLogTimeFieldName   = "std_time"
+	StdLogPrefixFieldName = "std_prefix"
+)
+
+// CaptureStdLog redirects the standard library's global logger into l, see CaptureLogger.
+func CaptureStdLog(l *Logger) (restore func()) {
+	return CaptureLogger(log.Default(), l)
+}
+
+// CaptureLogger sets l as the output of std. The header std writes according to its flags and prefix
+// is parsed back out of each line and logged as fields, the remainder becomes the message.
+// Lines are logged at l's print level. The returned function restores std's previous output, flags and prefix.
+func CaptureLogger(std *log.Logger, l *Logger) (restore func()) {
+	w, flags, prefix := std.Writer(), std.Flags(), std.Prefix()
+	std.SetOutput(&stdLogWriter{std: std, l: l})
+	return func() {
+		std.SetOutput(w)
+		std.SetFlags(flags)
+		std.SetPrefix(prefix)
+	}
+}
+
+type stdLogWriter struct {
+	std *log.Logger
+	l   *Logger
+}
+
+type stdLogLine struct {
+	prefix string
+	time   time.Time
+	file   string
+	msg    string
+}
+
+// parseStdLogLine undoes the header added by (*log.Logger).Output for the given flags and prefix.
+func parseStdLogLine(line string, flags int, prefix string) stdLogLine {
+	parsed := stdLogLine{}
+	if prefix != "" && flags&log.Lmsgprefix == 0 && strings.HasPrefix(line, prefix) {
+		parsed.prefix = prefix
+		line = line[len(prefix):]
+	}
+
+	if flags&(log.Ldate|log.Ltime|log.Lmicroseconds) != 0 {
+		var layout []string
+		if flags&log.Ldate != 0 {
+			layout = append(layout, "2006/01/02")
+		}
+		switch {
+		case flags&log.Lmicroseconds != 0:
+			layout = append(layout, "15:04:05.000000")
+		case flags&log.Ltime != 0:
+			layout = append(layout, "15:04:05")
+		}
+		loc := time.Local
+		if flags&log.LUTC != 0 {
+			loc = time.UTC
+		}
+		l := strings.Join(layout, " ")
+		if len(line) > len(l) && line[len(l)] == ' ' {
+			if t, err := time.ParseInLocation(l, line[:len(l)], loc); err == nil {
+				if flags&log.Ldate == 0 {
+					now := time.Now().In(loc)
+					t = time.Date(now.Year(), now.Month(), now.Day(),
+						t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), loc)
+				}
+				parsed.time = t
+				line = line[len(l)+1:]
+			}
+		}
+	}
+
+	if flags&(log.Lshortfile|log.Llongfile) != 0 {
+		if i := strings.Index(line, ": "); i > 0 {
+			if c := strings.LastIndexByte(line[:i], ':'); c > 0 {
+				if _, err := strconv.Atoi(line[c+1 : i]); err == nil {
+					parsed.file = line[:i]
+					line = line[i+2:]
+				}
+			}
+		}
+	}
+
+	if prefix != "" && flags&log.Lmsgprefix != 0 && strings.HasPrefix(line, prefix) {
+		parsed.prefix = prefix
+		line = line[len(prefix):]
+	}
+
+	parsed.msg = line
+	return parsed
+}
+
+func (w *stdLogWriter) Write(p []byte) (n int, err error) {
+	parsed := parseStdLogLine(string(bytes.TrimSuffix(p, []byte("\n"))), w.std.Flags(), w.std.Prefix())
+
+	c := w.l.config()
+	if e := c.event(c.printLevel); e != nil {
+		if parsed.prefix != "" {
+			e.Str(StdLogPrefixFieldName, strings.TrimSpace(parsed.prefix))
+		}
+		if !parsed.time.IsZero() {
+			e.Time(StdLogTimeFieldName, parsed.time)
+		}
+		if parsed.file != "" {
+			e.Str(CallerFileFieldName, parsed.file)
+		} else {
+			e = c.withAutoCaller(e)
+		}
+		e.Msg(parsed.msg)
+	}
+	return len(p), nil
+}
@@ -0,0 +1,87 @@
+package zwrap
+
+import (
+	"bytes"
+	"log"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+func TestParseStdLogLine(t *testing.T) {
+	ts := time.Date(2024, 5, 6, 7, 8, 9, 123456000, time.UTC)
+	tests := []struct {
+		name   string
+		flags  int
+		prefix string
+		line   string
+		want   stdLogLine
+	}{
+		{"bare", 0, "", "yeet", stdLogLine{msg: "yeet"}},
+		{"prefix", 0, "pre: ", "pre: yeet", stdLogLine{prefix: "pre: ", msg: "yeet"}},
+		{
+			"date time micro",
+			log.Ldate | log.Lmicroseconds | log.LUTC, "",
+			"2024/05/06 07:08:09.123456 yeet: with colon",
+			stdLogLine{time: ts, msg: "yeet: with colon"},
+		},
+		{
+			"all",
+			log.Ldate | log.Ltime | log.LUTC | log.Lshortfile | log.Lmsgprefix, "pre: ",
+			"2024/05/06 07:08:09 main.go:42: pre: yeet",
+			stdLogLine{prefix: "pre: ", time: ts.Truncate(time.Second), file: "main.go:42", msg: "yeet"},
+		},
+		{
+			"prefix first",
+			log.Ldate | log.LUTC | log.Llongfile, "[x] ",
+			"[x] 2024/05/06 /src/main.go:1: yeet",
+			stdLogLine{prefix: "[x] ", time: time.Date(2024, 5, 6, 0, 0, 0, 0, time.UTC), file: "/src/main.go:1", msg: "yeet"},
+		},
+		{"no file", log.Lshortfile, "", "yeet: nope", stdLogLine{msg: "yeet: nope"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := parseStdLogLine(tt.line, tt.flags, tt.prefix)
+			if !got.time.Equal(tt.want.time) {
+				t.Errorf("time = %v, want %v", got.time, tt.want.time)
+			}
+			got.time, tt.want.time = time.Time{}, time.Time{}
+			if got != tt.want {
+				t.Errorf("parseStdLogLine() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCaptureStdLog(t *testing.T) {
+	buf := new(bytes.Buffer)
+	zl := Wrap(zerolog.New(buf))
+
+	restore := CaptureStdLog(zl)
+	log.SetFlags(log.LstdFlags | log.Lshortfile)
+	log.SetPrefix("stdlog: ")
+	log.Printf("yeet %d", 1)
+	restore()
+
+	if log.Prefix() != "" || log.Flags() != log.LstdFlags {
+		t.Errorf("restore did not reset the standard logger: %q %d", log.Prefix(), log.Flags())
+	}
+
+	ms := decodeLines(t, buf)
+	if len(ms) != 1 {
+		t.Fatalf("expected 1 line, got %d: %s", len(ms), buf.String())
+	}
+	m := ms[0]
+	if m["message"] != "yeet 1" || m["level"] != "info" || m[StdLogPrefixFieldName] != "stdlog:" {
+		t.Errorf("unexpected record: %v", m)
+	}
+	if file, _ := m[CallerFileFieldName].(string); !strings.HasPrefix(file, "stdlog_test.go:") {
+		t.Errorf("expected caller file, got %v", m)
+	}
+	if _, ok := m[StdLogTimeFieldName]; !ok {
+		t.Errorf("expected %s field, got %v", StdLogTimeFieldName, m)
+	}
+}
@@ -0,0 +1,298 @@
+package zwrap
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+// SyslogFormat selects the syslog message format.
+type SyslogFormat int
+
+const (
+	SyslogRFC5424 SyslogFormat = iota
+	SyslogRFC3164
+)
+
+// SyslogFacility is a syslog facility code.
+type SyslogFacility int
+
+const (
+	SyslogKern SyslogFacility = iota
+	SyslogUser
+	SyslogMail
+	SyslogDaemon
+	SyslogAuth
+	SyslogSyslog
+	SyslogLocal0 SyslogFacility = iota + 10
+	SyslogLocal1
+	SyslogLocal2
+	SyslogLocal3
+	SyslogLocal4
+	SyslogLocal5
+	SyslogLocal6
+	SyslogLocal7
+)
+
+// Syslog severities, from RFC 5424.
+const (
+	SyslogEmergency = iota
+	SyslogAlert
+	SyslogCritical
+	SyslogError
+	SyslogWarning
+	SyslogNotice
+	SyslogInfo
+	SyslogDebug
+)
+
+// DefaultSyslogSDID is the SD-ID structured fields are written under unless SyslogOptions.SDID is set.
+// 32473 is the private enterprise number reserved for documentation, see RFC 5612.
+const DefaultSyslogSDID = "zwrap@32473"
+
+// SyslogOptions configure a SyslogWriter.
+type SyslogOptions struct {
+	// Network is "udp", "tcp", "unix" or "unixgram", or one of their variants such as "udp4". Stream networks
+	// use RFC 6587 octet-counting framing, datagram networks send one message per datagram.
+	Network string
+	// Addr is the address of the syslog server, or the socket path for unix networks.
+	Addr   string
+	Format SyslogFormat
+	// Facility defaults to SyslogUser, SyslogKern is reserved for the kernel.
+	Facility SyslogFacility
+	// Hostname defaults to os.Hostname.
+	Hostname string
+	// AppName defaults to the base name of the executable.
+	AppName string
+	// SDID is the SD-ID of the RFC 5424 SD-ELEMENT holding the structured fields, DefaultSyslogSDID if empty.
+	SDID string
+	// DialTimeout bounds each (re)connection attempt and each write, 5 seconds if zero.
+	DialTimeout time.Duration
+}
+
+// SyslogWriter is an io.Writer that forwards zerolog JSON events to a syslog server. The event's level picks
+// the severity, see syslogSeverity, its message becomes the MSG and its other fields an SD-ELEMENT
+// (RFC 5424) or key=value pairs appended to the message (RFC 3164). The connection is made on first use and
+// remade once per write if sending fails.
+type SyslogWriter struct {
+	opts SyslogOptions
+	pid  string
+	conn *redialConn
+}
+
+// NewSyslogWriter returns a SyslogWriter sending to opts.Addr.
+func NewSyslogWriter(opts SyslogOptions) *SyslogWriter {
+	if opts.Facility == SyslogKern {
+		opts.Facility = SyslogUser
+	}
+	if opts.Hostname == "" {
+		opts.Hostname, _ = os.Hostname()
+	}
+	if opts.AppName == "" {
+		opts.AppName = filepath.Base(os.Args[0])
+	}
+	if opts.SDID == "" {
+		opts.SDID = DefaultSyslogSDID
+	}
+	if opts.DialTimeout == 0 {
+		opts.DialTimeout = 5 * time.Second
+	}
+	return &SyslogWriter{
+		opts: opts,
+		pid:  strconv.Itoa(os.Getpid()),
+		conn: &redialConn{network: opts.Network, addr: opts.Addr, timeout: opts.DialTimeout},
+	}
+}
+
+// syslogSeverity maps a record's level onto a syslog severity, refined by its LevelAliasFieldName if that is set.
+func syslogSeverity(r record) int {
+	if LevelAliasFieldName != "" {
+		switch r.fields[LevelAliasFieldName] {
+		case "notice":
+			return SyslogNotice
+		case "verbose":
+			return SyslogDebug
+		}
+	}
+	switch r.level {
+	case zerolog.PanicLevel:
+		return SyslogAlert
+	case zerolog.FatalLevel:
+		return SyslogCritical
+	case zerolog.ErrorLevel:
+		return SyslogError
+	case zerolog.WarnLevel:
+		return SyslogWarning
+	case zerolog.DebugLevel, zerolog.TraceLevel:
+		return SyslogDebug
+	default:
+		return SyslogInfo
+	}
+}
+
+func (w *SyslogWriter) Write(p []byte) (n int, err error) {
+	err = splitLines(p, func(line []byte) error {
+		r, err := parseRecord(line)
+		if err != nil {
+			return err
+		}
+		return w.send(w.format(r))
+	})
+	if err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// Close closes the connection to the syslog server, if any.
+func (w *SyslogWriter) Close() error {
+	return w.conn.Close()
+}
+
+func (w *SyslogWriter) stream() bool {
+	return strings.HasPrefix(w.opts.Network, "tcp") || w.opts.Network == "unix"
+}
+
+func (w *SyslogWriter) send(msg []byte) error {
+	if w.stream() {
+		msg = append(strconv.AppendInt(nil, int64(len(msg)), 10), append([]byte{' '}, msg...)...)
+	}
+	return w.conn.write(msg)
+}
+
+func (w *SyslogWriter) format(r record) []byte {
+	pri := int(w.opts.Facility)*8 + syslogSeverity(r)
+	dropLevelAlias(r)
+	t := r.time
+	if t.IsZero() {
+		t = time.Now()
+	}
+
+	b := append(make([]byte, 0, 256), '<')
+	b = strconv.AppendInt(b, int64(pri), 10)
+	b = append(b, '>')
+	if w.opts.Format == SyslogRFC3164 {
+		b = t.AppendFormat(b, time.Stamp)
+		b = append(b, ' ')
+		b = append(b, syslogHeaderField(w.opts.Hostname, 255)...)
+		b = append(b, ' ')
+		b = append(b, syslogHeaderField(w.opts.AppName, 32)...)
+		b = append(b, '[')
+		b = append(b, w.pid...)
+		b = append(b, "]: "...)
+		b = append(b, r.msg...)
+		for _, k := range sortedKeys(r.fields) {
+			b = append(b, ' ')
+			b = append(b, k...)
+			b = append(b, '=')
+			b = append(b, syslogValue(r.fields[k])...)
+		}
+		return b
+	}
+
+	b = append(b, "1 "...)
+	b = t.AppendFormat(b, "2006-01-02T15:04:05.000000Z07:00")
+	b = append(b, ' ')
+	b = append(b, syslogHeaderField(w.opts.Hostname, 255)...)
+	b = append(b, ' ')
+	b = append(b, syslogHeaderField(w.opts.AppName, 48)...)
+	b = append(b, ' ')
+	b = append(b, w.pid...)
+	b = append(b, " - "...)
+	if len(r.fields) == 0 {
+		b = append(b, '-')
+	} else {
+		b = append(b, '[')
+		b = append(b, w.opts.SDID...)
+		for _, k := range sortedKeys(r.fields) {
+			b = append(b, ' ')
+			b = append(b, syslogSDName(k)...)
+			b = append(b, `="`...)
+			b = appendSDValue(b, syslogValue(r.fields[k]))
+			b = append(b, '"')
+		}
+		b = append(b, ']')
+	}
+	if r.msg != "" {
+		b = append(b, ' ')
+		b = append(b, r.msg...)
+	}
+	return b
+}
+
+// dropLevelAlias removes the LevelAliasFieldName field, once it has been taken into account by syslogSeverity.
+func dropLevelAlias(r record) {
+	if LevelAliasFieldName != "" {
+		delete(r.fields, LevelAliasFieldName)
+	}
+}
+
+// syslogHeaderField returns s restricted to printable US-ASCII and max characters, or "-" if that leaves nothing.
+func syslogHeaderField(s string, max int) string {
+	s = strings.Map(func(r rune) rune {
+		if r < 33 || r > 126 {
+			return -1
+		}
+		return r
+	}, s)
+	if len(s) > max {
+		s = s[:max]
+	}
+	if s == "" {
+		return "-"
+	}
+	return s
+}
+
+// syslogSDName returns name as a valid SD-NAME, replacing the characters RFC 5424 disallows with '_'.
+func syslogSDName(name string) string {
+	name = strings.Map(func(r rune) rune {
+		if r < 33 || r > 126 || r == '=' || r == ']' || r == '"' || r == ' ' {
+			return '_'
+		}
+		return r
+	}, name)
+	if len(name) > 32 {
+		name = name[:32]
+	}
+	if name == "" {
+		return "_"
+	}
+	return name
+}
+
+// appendSDValue escapes '"', '\' and ']' in a PARAM-VALUE.
+func appendSDValue(b []byte, v string) []byte {
+	for i := 0; i < len(v); i++ {
+		switch v[i] {
+		case '"', '\\', ']':
+			b = append(b, '\\')
+		}
+		b = append(b, v[i])
+	}
+	return b
+}
+
+// syslogValue formats a decoded field value, nested objects and arrays are written as JSON.
+func syslogValue(v interface{}) string {
+	switch v := v.(type) {
+	case string:
+		return v
+	case json.Number:
+		return v.String()
+	case bool:
+		return strconv.FormatBool(v)
+	default:
+		var buf bytes.Buffer
+		if err := json.NewEncoder(&buf).Encode(v); err != nil {
+			return ""
+		}
+		return string(bytes.TrimSuffix(buf.Bytes(), []byte("\n")))
+	}
+}
@@ -0,0 +1,259 @@
+package zwrap
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+// withLevelAliases opts in to LevelAliasFieldName for the duration of a test.
+func withLevelAliases(t *testing.T) {
+	t.Helper()
+	saved := LevelAliasFieldName
+	LevelAliasFieldName = "level_alias"
+	t.Cleanup(func() { LevelAliasFieldName = saved })
+}
+
+func TestSyslogSeverity(t *testing.T) {
+	r := record{level: zerolog.InfoLevel, fields: map[string]interface{}{"level_alias": "notice"}}
+	if got := syslogSeverity(r); got != SyslogInfo {
+		t.Errorf("expected aliases to be ignored unless enabled, got severity %d", got)
+	}
+
+	withLevelAliases(t)
+	tests := []struct {
+		level zerolog.Level
+		alias string
+		want  int
+	}{
+		{zerolog.PanicLevel, "", SyslogAlert},
+		{zerolog.FatalLevel, "", SyslogCritical},
+		{zerolog.ErrorLevel, "", SyslogError},
+		{zerolog.WarnLevel, "", SyslogWarning},
+		{zerolog.InfoLevel, "notice", SyslogNotice},
+		{zerolog.InfoLevel, "", SyslogInfo},
+		{zerolog.NoLevel, "", SyslogInfo},
+		{zerolog.DebugLevel, "", SyslogDebug},
+		{zerolog.TraceLevel, "verbose", SyslogDebug},
+		{zerolog.TraceLevel, "", SyslogDebug},
+	}
+	for _, tt := range tests {
+		r := record{level: tt.level, fields: map[string]interface{}{}}
+		if tt.alias != "" {
+			r.fields[LevelAliasFieldName] = tt.alias
+		}
+		if got := syslogSeverity(r); got != tt.want {
+			t.Errorf("syslogSeverity(%v, %q) = %d, want %d", tt.level, tt.alias, got, tt.want)
+		}
+	}
+}
+
+func TestLevelAlias_ForceLevel(t *testing.T) {
+	withLevelAliases(t)
+	buf := new(bytes.Buffer)
+	zl := Wrap(zerolog.New(buf))
+	zl.ForceLevel(zerolog.InfoLevel)
+	zl.Noticef("a %d", 1)
+	zl.Verbosef("b")
+
+	ms := decodeLines(t, buf)
+	if len(ms) != 2 {
+		t.Fatalf("expected 2 lines, got %d: %s", len(ms), buf.String())
+	}
+	if ms[0]["level"] != "info" || ms[0]["message"] != "a 1" || ms[0]["level_alias"] != "notice" {
+		t.Errorf("expected the notice alias to survive the force level, got %v", ms[0])
+	}
+	if ms[1]["level"] != "info" || ms[1]["level_alias"] != "verbose" {
+		t.Errorf("expected the verbose alias to survive the force level, got %v", ms[1])
+	}
+}
+
+// readSyslogFrame reads one RFC 6587 octet-counted frame.
+func readSyslogFrame(t *testing.T, r *bufio.Reader) string {
+	t.Helper()
+	length, err := r.ReadString(' ')
+	if err != nil {
+		t.Fatalf("failed to read frame length: %v", err)
+	}
+	n, err := strconv.Atoi(strings.TrimSuffix(length, " "))
+	if err != nil {
+		t.Fatalf("invalid frame length %q: %v", length, err)
+	}
+	msg := make([]byte, n)
+	if _, err := io.ReadFull(r, msg); err != nil {
+		t.Fatalf("failed to read frame: %v", err)
+	}
+	return string(msg)
+}
+
+func TestSyslogWriter_UDP(t *testing.T) {
+	withLevelAliases(t)
+	pc, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer pc.Close()
+
+	w := NewSyslogWriter(SyslogOptions{Network: "udp", Addr: pc.LocalAddr().String(),
+		Facility: SyslogLocal0, Hostname: "host", AppName: "app"})
+	defer w.Close()
+	zl := Wrap(zerolog.New(w).With().Timestamp().Logger())
+
+	zl.Child().WithFields(map[string]interface{}{"user": `ka"y]os`, "n": 1, "bad key": true}).Noticef("hello %s", "world")
+	zl.Error("no fields")
+
+	buf := make([]byte, 2048)
+	_ = pc.SetReadDeadline(time.Now().Add(5 * time.Second))
+	var got []string
+	for i := 0; i < 2; i++ {
+		n, _, err := pc.ReadFrom(buf)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got = append(got, string(buf[:n]))
+	}
+
+	pid := strconv.Itoa(os.Getpid())
+	want := []*regexp.Regexp{
+		regexp.MustCompile(`^<133>1 \d{4}-\d\d-\d\dT\d\d:\d\d:\d\d\.\d{6}\S+ host app ` + pid +
+			` - \[zwrap@32473 bad_key="true" n="1" user="ka\\"y\\]os"\] hello world$`),
+		regexp.MustCompile(`^<131>1 \S+ host app ` + pid + ` - - no fields$`),
+	}
+	for i, re := range want {
+		if !re.MatchString(got[i]) {
+			t.Errorf("message %d: %q does not match %s", i, got[i], re)
+		}
+	}
+}
+
+func TestSyslogWriter_TCPReconnect(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ln.Close()
+	conns := make(chan net.Conn, 2)
+	go func() {
+		for {
+			c, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			conns <- c
+		}
+	}()
+
+	w := NewSyslogWriter(SyslogOptions{Network: "tcp", Addr: ln.Addr().String(), Hostname: "host", AppName: "app"})
+	defer w.Close()
+	zl := Wrap(zerolog.New(w))
+
+	zl.Warn("first")
+	c := <-conns
+	if msg := readSyslogFrame(t, bufio.NewReader(c)); !strings.HasPrefix(msg, "<12>1 ") || !strings.HasSuffix(msg, " - - first") {
+		t.Errorf("unexpected message: %q", msg)
+	}
+	c.Close()
+
+	// the first writes after the server hung up may still succeed locally, keep writing until one reconnects.
+	deadline := time.After(5 * time.Second)
+	for {
+		zl.Info("after reconnect")
+		select {
+		case c := <-conns:
+			defer c.Close()
+			if msg := readSyslogFrame(t, bufio.NewReader(c)); !strings.HasSuffix(msg, " - - after reconnect") {
+				t.Errorf("unexpected message: %q", msg)
+			}
+			return
+		case <-deadline:
+			t.Fatal("writer did not reconnect")
+		case <-time.After(10 * time.Millisecond):
+		}
+	}
+}
+
+func TestSyslogWriter_TCPStalled(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ln.Close()
+	// accept connections but never read from them, so the socket buffers fill up.
+	accepted := make(chan net.Conn, 16)
+	go func() {
+		for {
+			c, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			accepted <- c
+		}
+	}()
+
+	w := NewSyslogWriter(SyslogOptions{Network: "tcp", Addr: ln.Addr().String(), DialTimeout: 100 * time.Millisecond})
+	event := []byte(`{"level":"info","message":"` + strings.Repeat("x", 1<<20) + `"}` + "\n")
+	stop := make(chan struct{})
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		for {
+			select {
+			case <-stop:
+				return
+			default:
+				_, _ = w.Write(event)
+			}
+		}
+	}()
+	defer func() {
+		close(stop)
+		<-stopped
+		w.Close()
+	}()
+
+	// a write that times out drops the connection, so the writer dialing again shows it did not block.
+	deadline := time.After(10 * time.Second)
+	for n := 0; n < 2; n++ {
+		select {
+		case c := <-accepted:
+			defer c.Close()
+		case <-deadline:
+			t.Fatal("writes to a server that stopped reading did not time out")
+		}
+	}
+}
+
+func TestSyslogWriter_UnixRFC3164(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "log.sock")
+	pc, err := net.ListenPacket("unixgram", path)
+	if err != nil {
+		t.Skipf("unixgram sockets unavailable: %v", err)
+	}
+	defer pc.Close()
+
+	w := NewSyslogWriter(SyslogOptions{Network: "unixgram", Addr: path, Format: SyslogRFC3164,
+		Facility: SyslogDaemon, Hostname: "host", AppName: "app"})
+	defer w.Close()
+	Wrap(zerolog.New(w).With().Timestamp().Logger()).WithFields(map[string]interface{}{"k": "v"}).Warn("disk low")
+
+	buf := make([]byte, 2048)
+	_ = pc.SetReadDeadline(time.Now().Add(5 * time.Second))
+	n, _, err := pc.ReadFrom(buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	re := regexp.MustCompile(`^<28>[A-Z][a-z]{2} [ \d]\d \d\d:\d\d:\d\d host app\[` + strconv.Itoa(os.Getpid()) + `\]: disk low k=v$`)
+	if !re.MatchString(string(buf[:n])) {
+		t.Errorf("%q does not match %s", buf[:n], re)
+	}
+}
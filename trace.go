@@ -0,0 +1,130 @@
+package zwrap
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"strings"
+
+	"github.com/rs/zerolog"
+)
+
+// Field names written by ReportTrace.
+var (
+	TraceIDFieldName    = "trace_id"
+	SpanIDFieldName     = "span_id"
+	TraceFlagsFieldName = "trace_flags"
+)
+
+// SpanContext is the part of an OpenTelemetry trace.SpanContext that ReportTrace logs. OTel's own type returns
+// named types rather than strings, so it needs a small shim, for example:
+//
+//	type otelSpan struct{ trace.SpanContext }
+//
+//	func (s otelSpan) TraceID() string  { return s.SpanContext.TraceID().String() }
+//	func (s otelSpan) SpanID() string   { return s.SpanContext.SpanID().String() }
+//	func (s otelSpan) TraceFlags() byte { return byte(s.SpanContext.TraceFlags()) }
+type SpanContext interface {
+	// TraceID returns the trace ID as 32 lowercase hex digits.
+	TraceID() string
+	// SpanID returns the span ID as 16 lowercase hex digits.
+	SpanID() string
+	TraceFlags() byte
+}
+
+type spanContextKey struct{}
+
+// ContextWithSpanContext returns a copy of ctx carrying sc.
+func ContextWithSpanContext(ctx context.Context, sc SpanContext) context.Context {
+	return context.WithValue(ctx, spanContextKey{}, sc)
+}
+
+// ContextWithTraceparent returns a copy of ctx carrying the span context of a W3C traceparent header value.
+// ctx is returned as is if traceparent is invalid.
+func ContextWithTraceparent(ctx context.Context, traceparent string) context.Context {
+	sc, err := ParseTraceparent(traceparent)
+	if err != nil {
+		return ctx
+	}
+	return ContextWithSpanContext(ctx, sc)
+}
+
+// SpanContextFromContext returns the SpanContext carried by ctx, if any.
+func SpanContextFromContext(ctx context.Context) (SpanContext, bool) {
+	sc, ok := ctx.Value(spanContextKey{}).(SpanContext)
+	return sc, ok
+}
+
+var errInvalidTraceparent = errors.New("invalid traceparent")
+
+type traceparent struct {
+	traceID string
+	spanID  string
+	flags   byte
+}
+
+func (t traceparent) TraceID() string  { return t.traceID }
+func (t traceparent) SpanID() string   { return t.spanID }
+func (t traceparent) TraceFlags() byte { return t.flags }
+
+// ParseTraceparent parses a W3C traceparent header value, "version-traceid-spanid-flags".
+// As the spec asks, versions other than 00 are accepted as long as they start with the version 00 fields.
+func ParseTraceparent(s string) (SpanContext, error) {
+	s = strings.TrimSpace(s)
+	if len(s) < 55 || (len(s) > 55 && s[55] != '-') || s[2] != '-' || s[35] != '-' || s[52] != '-' {
+		return nil, errInvalidTraceparent
+	}
+	version, traceID, spanID, flags := s[:2], s[3:35], s[36:52], s[53:55]
+	if version == "ff" || (version == "00" && len(s) != 55) {
+		return nil, errInvalidTraceparent
+	}
+	for _, field := range []string{version, traceID, spanID, flags} {
+		if !isLowerHex(field) {
+			return nil, errInvalidTraceparent
+		}
+	}
+	if strings.Trim(traceID, "0") == "" || strings.Trim(spanID, "0") == "" {
+		return nil, errInvalidTraceparent
+	}
+	var f [1]byte
+	_, _ = hex.Decode(f[:], []byte(flags))
+	return traceparent{traceID: traceID, spanID: spanID, flags: f[0]}, nil
+}
+
+func isLowerHex(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if !('0' <= s[i] && s[i] <= '9' || 'a' <= s[i] && s[i] <= 'f') {
+			return false
+		}
+	}
+	return true
+}
+
+// ReportTrace controls whether events logged with a context carrying a SpanContext, see ContextWithSpanContext
+// and ContextWithTraceparent, get its trace ID, span ID and flags as fields.
+func (l *Logger) ReportTrace(b bool) {
+	l.update(func(c *config) {
+		c.reportTrace = b
+	})
+}
+
+func (l *Logger) WithReportTrace() *Logger {
+	l = l.withTarget()
+	l.ReportTrace(true)
+	return l
+}
+
+// traceHook stamps the SpanContext of an event's context onto it, alongside prefixHook.
+type traceHook struct{}
+
+func (traceHook) Run(e *zerolog.Event, _ zerolog.Level, _ string) {
+	sc, ok := SpanContextFromContext(e.GetCtx())
+	if !ok {
+		return
+	}
+	var flags [2]byte
+	hex.Encode(flags[:], []byte{sc.TraceFlags()})
+	e.Str(TraceIDFieldName, sc.TraceID()).
+		Str(SpanIDFieldName, sc.SpanID()).
+		Str(TraceFlagsFieldName, string(flags[:]))
+}
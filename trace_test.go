@@ -0,0 +1,88 @@
+package zwrap
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/rs/zerolog"
+)
+
+func TestParseTraceparent(t *testing.T) {
+	tests := []struct {
+		name    string
+		in      string
+		wantErr bool
+		traceID string
+		spanID  string
+		flags   byte
+	}{
+		{"sampled", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01", false,
+			"4bf92f3577b34da6a3ce929d0e0e4736", "00f067aa0ba902b7", 1},
+		{"not sampled", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-00", false,
+			"4bf92f3577b34da6a3ce929d0e0e4736", "00f067aa0ba902b7", 0},
+		{"future version", "cc-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01-extra", false,
+			"4bf92f3577b34da6a3ce929d0e0e4736", "00f067aa0ba902b7", 1},
+		{"version 00 with extra", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01-extra", true, "", "", 0},
+		{"version ff", "ff-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01", true, "", "", 0},
+		{"uppercase", "00-4BF92F3577B34DA6A3CE929D0E0E4736-00f067aa0ba902b7-01", true, "", "", 0},
+		{"zero trace ID", "00-00000000000000000000000000000000-00f067aa0ba902b7-01", true, "", "", 0},
+		{"zero span ID", "00-4bf92f3577b34da6a3ce929d0e0e4736-0000000000000000-01", true, "", "", 0},
+		{"short", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7", true, "", "", 0},
+		{"empty", "", true, "", "", 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sc, err := ParseTraceparent(tt.in)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseTraceparent(%q) error = %v, wantErr %v", tt.in, err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if sc.TraceID() != tt.traceID || sc.SpanID() != tt.spanID || sc.TraceFlags() != tt.flags {
+				t.Errorf("ParseTraceparent(%q) = %s %s %d", tt.in, sc.TraceID(), sc.SpanID(), sc.TraceFlags())
+			}
+		})
+	}
+}
+
+type fakeSpanContext struct{}
+
+func (fakeSpanContext) TraceID() string  { return "0af7651916cd43dd8448eb211c80319c" }
+func (fakeSpanContext) SpanID() string   { return "b7ad6b7169203331" }
+func (fakeSpanContext) TraceFlags() byte { return 1 }
+
+func TestLogger_ReportTrace(t *testing.T) {
+	buf := new(bytes.Buffer)
+	zl := Wrap(zerolog.New(buf))
+	spanCtx := ContextWithSpanContext(context.Background(), fakeSpanContext{})
+	headerCtx := ContextWithTraceparent(context.Background(), "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-00")
+
+	zl.InfoContext(spanCtx, "not reported")
+	zl.ReportTrace(true)
+	zl.InfoContext(spanCtx, "from span")
+	zl.InfoContext(headerCtx, "from header")
+	zl.InfoContext(ContextWithTraceparent(context.Background(), "garbage"), "invalid header")
+	zl.Info("no context")
+
+	ms := decodeLines(t, buf)
+	want := []struct{ msg, traceID, spanID, flags string }{
+		{"not reported", "", "", ""},
+		{"from span", "0af7651916cd43dd8448eb211c80319c", "b7ad6b7169203331", "01"},
+		{"from header", "4bf92f3577b34da6a3ce929d0e0e4736", "00f067aa0ba902b7", "00"},
+		{"invalid header", "", "", ""},
+		{"no context", "", "", ""},
+	}
+	if len(ms) != len(want) {
+		t.Fatalf("expected %d lines, got %d: %s", len(want), len(ms), buf.String())
+	}
+	for i, w := range want {
+		traceID, _ := ms[i]["trace_id"].(string)
+		spanID, _ := ms[i]["span_id"].(string)
+		flags, _ := ms[i]["trace_flags"].(string)
+		if ms[i]["message"] != w.msg || traceID != w.traceID || spanID != w.spanID || flags != w.flags {
+			t.Errorf("line %d: got %v, want %+v", i, ms[i], w)
+		}
+	}
+}
@@ -1,12 +1,89 @@
 package zwrap
 
 import (
-	"strings"
+	"fmt"
+	"strconv"
 	"sync"
+	"unsafe"
 )
 
-var strBufs = &sync.Pool{
+var msgBufs = &sync.Pool{
 	New: func() interface{} {
-		return new(strings.Builder)
+		b := make([]byte, 0, 256)
+		return &b
 	},
 }
+
+// putMsgBuf returns buf to msgBufs unless it grew too large to be worth keeping.
+func putMsgBuf(buf *[]byte) {
+	if cap(*buf) > 64<<10 {
+		return
+	}
+	*buf = (*buf)[:0]
+	msgBufs.Put(buf)
+}
+
+// appendArg appends v to b as fmt.Sprint would, avoiding reflection for common types.
+func appendArg(b []byte, v interface{}) []byte {
+	switch v := v.(type) {
+	case string:
+		return append(b, v...)
+	case nil:
+		return append(b, "<nil>"...)
+	case bool:
+		return strconv.AppendBool(b, v)
+	case int:
+		return strconv.AppendInt(b, int64(v), 10)
+	case int8:
+		return strconv.AppendInt(b, int64(v), 10)
+	case int16:
+		return strconv.AppendInt(b, int64(v), 10)
+	case int32:
+		return strconv.AppendInt(b, int64(v), 10)
+	case int64:
+		return strconv.AppendInt(b, v, 10)
+	case uint:
+		return strconv.AppendUint(b, uint64(v), 10)
+	case uint8:
+		return strconv.AppendUint(b, uint64(v), 10)
+	case uint16:
+		return strconv.AppendUint(b, uint64(v), 10)
+	case uint32:
+		return strconv.AppendUint(b, uint64(v), 10)
+	case uint64:
+		return strconv.AppendUint(b, v, 10)
+	case float32:
+		return strconv.AppendFloat(b, float64(v), 'g', -1, 32)
+	case float64:
+		return strconv.AppendFloat(b, v, 'g', -1, 64)
+	case []byte:
+		b = append(b, '[')
+		for i, c := range v {
+			if i > 0 {
+				b = append(b, ' ')
+			}
+			b = strconv.AppendUint(b, uint64(c), 10)
+		}
+		return append(b, ']')
+	case fmt.Formatter:
+		return fmt.Append(b, v)
+	case error:
+		if isNilValue(v) {
+			// let fmt deal with methods called on a nil receiver.
+			return fmt.Append(b, v)
+		}
+		return append(b, v.Error()...)
+	case fmt.Stringer:
+		if isNilValue(v) {
+			return fmt.Append(b, v)
+		}
+		return append(b, v.String()...)
+	default:
+		return fmt.Append(b, v)
+	}
+}
+
+// isNilValue reports whether the data word of i is nil, e.g. a typed nil pointer.
+func isNilValue(i interface{}) bool {
+	return (*[2]uintptr)(unsafe.Pointer(&i))[1] == 0
+}
@@ -0,0 +1,79 @@
+package zwrap
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+type nilStringer struct{ s string }
+
+func (n *nilStringer) String() string { return n.s }
+
+func TestAppendArg(t *testing.T) {
+	var nilPtr *nilStringer
+	values := []interface{}{
+		"yeet", nil, true, 1, int8(-2), int16(3), int32(-4), int64(5),
+		uint(6), uint8(7), uint16(8), uint32(9), uint64(10),
+		float32(1.5), 0.1, 1e21, []byte("hi"), []byte{},
+		errors.New("boom"), time.Second, &nilStringer{"str"}, nilPtr,
+		zerolog.InfoLevel, struct{ A int }{1}, []string{"a", "b"},
+	}
+	for _, v := range values {
+		want := fmt.Sprint(v)
+		if got := string(appendArg(nil, v)); got != want {
+			t.Errorf("appendArg(%#v) = %q, want %q", v, got, want)
+		}
+	}
+}
+
+func TestLogger_ZeroAlloc(t *testing.T) {
+	if raceEnabled {
+		t.Skip("allocations are not representative with the race detector enabled")
+	}
+	zl := Wrap(zerolog.New(io.Discard).Level(zerolog.InfoLevel))
+	err := errors.New("boom")
+	// messages that have to be built cost the one allocation of the string handed to zerolog.
+	tests := map[string]struct {
+		fn     func()
+		allocs float64
+	}{
+		"Info":            {func() { zl.Info("yeet") }, 0},
+		"Info multi":      {func() { zl.Info("yeet", 1, err, true) }, 1},
+		"Infof":           {func() { zl.Infof("yeet %d %s", 1, "yeet") }, 1},
+		"Printf no args":  {func() { zl.Printf("yeet") }, 0},
+		"Debugf disabled": {func() { zl.Debugf("yeet %v", err) }, 0},
+		"Debug disabled":  {func() { zl.Debug("yeet", err) }, 0},
+	}
+	for name, tt := range tests {
+		if allocs := testing.AllocsPerRun(100, tt.fn); allocs != tt.allocs {
+			t.Errorf("%s: %v allocs per run, want %v", name, allocs, tt.allocs)
+		}
+	}
+}
+
+func TestLogger_MessageNotAliased(t *testing.T) {
+	hook := &msgCaptureHook{}
+	zl := Wrap(zerolog.New(io.Discard).Hook(hook))
+	zl.Info("first", 1)
+	zl.Info("second", 2)
+	zl.Infof("third %d", 3)
+	p := []byte("fourth\n")
+	_, _ = zl.Write(p)
+	copy(p, "XXXXXX")
+
+	want := []string{"first 1", "second 2", "third 3", "fourth"}
+	if len(hook.msgs) != len(want) {
+		t.Fatalf("expected %d messages, got %q", len(want), hook.msgs)
+	}
+	for i := range want {
+		if hook.msgs[i] != want[i] {
+			t.Errorf("hook kept %q, want %q", hook.msgs, want)
+			break
+		}
+	}
+}
@@ -0,0 +1,210 @@
+package zwrap
+
+import (
+	"bytes"
+	"io"
+
+	"github.com/rs/zerolog"
+)
+
+// EchoLvl mirrors github.com/labstack/gommon/log.Lvl, the level type of echo.Logger.
+type EchoLvl uint8
+
+const (
+	EchoDebug EchoLvl = iota + 1
+	EchoInfo
+	EchoWarn
+	EchoError
+	EchoOff
+)
+
+// EchoJSON mirrors github.com/labstack/gommon/log.JSON.
+type EchoJSON map[string]interface{}
+
+// EchoCompatLogger mirrors the method set of github.com/labstack/echo/v4.Logger without importing echo.
+// As with LogrCompatSink, methods referring to gommon's types need a small shim in the importing package.
+type EchoCompatLogger interface {
+	Output() io.Writer
+	SetOutput(w io.Writer)
+	Prefix() string
+	SetPrefix(p string)
+	Level() EchoLvl
+	SetLevel(v EchoLvl)
+	SetHeader(h string)
+	Print(i ...interface{})
+	Printf(format string, args ...interface{})
+	Printj(j EchoJSON)
+	Debug(i ...interface{})
+	Debugf(format string, args ...interface{})
+	Debugj(j EchoJSON)
+	Info(i ...interface{})
+	Infof(format string, args ...interface{})
+	Infoj(j EchoJSON)
+	Warn(i ...interface{})
+	Warnf(format string, args ...interface{})
+	Warnj(j EchoJSON)
+	Error(i ...interface{})
+	Errorf(format string, args ...interface{})
+	Errorj(j EchoJSON)
+	Fatal(i ...interface{})
+	Fatalj(j EchoJSON)
+	Fatalf(format string, args ...interface{})
+	Panic(i ...interface{})
+	Panicj(j EchoJSON)
+	Panicf(format string, args ...interface{})
+}
+
+// FiberCompatLogger mirrors github.com/gofiber/fiber/v2/log.CommonLogger, which Logger implements as is.
+// For fiber's logger middleware, use a LevelWriter as its Output.
+type FiberCompatLogger interface {
+	Trace(v ...interface{})
+	Debug(v ...interface{})
+	Info(v ...interface{})
+	Warn(v ...interface{})
+	Error(v ...interface{})
+	Fatal(v ...interface{})
+	Panic(v ...interface{})
+	Tracef(format string, v ...interface{})
+	Debugf(format string, v ...interface{})
+	Infof(format string, v ...interface{})
+	Warnf(format string, v ...interface{})
+	Errorf(format string, v ...interface{})
+	Fatalf(format string, v ...interface{})
+	Panicf(format string, v ...interface{})
+	Tracew(msg string, keysAndValues ...interface{})
+	Debugw(msg string, keysAndValues ...interface{})
+	Infow(msg string, keysAndValues ...interface{})
+	Warnw(msg string, keysAndValues ...interface{})
+	Errorw(msg string, keysAndValues ...interface{})
+	Fatalw(msg string, keysAndValues ...interface{})
+	Panicw(msg string, keysAndValues ...interface{})
+}
+
+var (
+	_ EchoCompatLogger  = (*EchoLogger)(nil)
+	_ FiberCompatLogger = &Logger{}
+)
+
+// EchoLogger adapts a Logger to echo.Logger. The *j methods write the entries of the map as fields.
+type EchoLogger struct {
+	*Logger
+}
+
+// NewEchoLogger returns an EchoLogger writing through l.
+func NewEchoLogger(l *Logger) *EchoLogger {
+	return &EchoLogger{Logger: l}
+}
+
+// echoToZlogLevel maps echo levels onto zerolog levels, echo's Debug through Error are zerolog's shifted by one.
+func echoToZlogLevel(level EchoLvl) zerolog.Level {
+	if level >= EchoOff {
+		return zerolog.Disabled
+	}
+	return castToZlogLevel(int(level) - 1)
+}
+
+func zlogToEchoLevel(level zerolog.Level) EchoLvl {
+	switch {
+	case level == zerolog.Disabled:
+		return EchoOff
+	case level < zerolog.DebugLevel:
+		return EchoDebug
+	case level > zerolog.ErrorLevel:
+		return EchoError
+	default:
+		return EchoLvl(level + 1)
+	}
+}
+
+// Output returns a writer logging each line written to it at the Logger's print level, as echo's StdLogger expects.
+func (e *EchoLogger) Output() io.Writer {
+	return e.Logger
+}
+
+// SetOutput redirects the underlying Logger, and every Logger sharing it, to w.
+func (e *EchoLogger) SetOutput(w io.Writer) {
+	e.update(func(c *config) {
+		c.base = c.base.Output(w)
+	})
+}
+
+func (e *EchoLogger) Level() EchoLvl {
+	return zlogToEchoLevel(e.ZLogger().GetLevel())
+}
+
+func (e *EchoLogger) SetLevel(v EchoLvl) {
+	e.Logger.SetLevel(echoToZlogLevel(v))
+}
+
+// SetHeader is a no-op, the format of each line is left to zerolog.
+func (e *EchoLogger) SetHeader(string) {}
+
+func (c *config) printj(e *zerolog.Event, preserve bool, msg string, j EchoJSON) {
+	if e = c.prepare(e, preserve); e == nil {
+		return
+	}
+	e.Fields(map[string]interface{}(j)).Msg(msg)
+}
+
+func (e *EchoLogger) Printj(j EchoJSON) {
+	c := e.config()
+	c.printj(c.zl.WithLevel(c.printLevel), false, "", j)
+}
+
+func (e *EchoLogger) Debugj(j EchoJSON) {
+	c := e.config()
+	c.printj(c.zl.Debug(), false, "", j)
+}
+
+func (e *EchoLogger) Infoj(j EchoJSON) {
+	c := e.config()
+	c.printj(c.zl.Info(), false, "", j)
+}
+
+func (e *EchoLogger) Warnj(j EchoJSON) {
+	c := e.config()
+	c.printj(c.zl.Warn(), false, "", j)
+}
+
+func (e *EchoLogger) Errorj(j EchoJSON) {
+	c := e.config()
+	c.printj(c.zl.Error(), false, "", j)
+}
+
+func (e *EchoLogger) Fatalj(j EchoJSON) {
+	c := e.config()
+	if msg, _, ok := c.checkFatalBypass(""); ok {
+		c.printj(c.zl.Fatal(), true, msg, j)
+		return
+	}
+	c.printj(c.zl.Error(), false, "[FATAL BYPASSED]", j)
+}
+
+func (e *EchoLogger) Panicj(j EchoJSON) {
+	c := e.config()
+	if msg, _, ok := c.checkPanicBypass(""); ok {
+		c.printj(c.zl.Panic(), true, msg, j)
+		return
+	}
+	c.printj(c.zl.Error(), false, "[PANIC BYPASSED]", j)
+}
+
+// LevelWriter returns an io.Writer that logs each write, minus its trailing newline, at level.
+// It is meant for frameworks that only take a writer, such as gin.DefaultWriter, gin.DefaultErrorWriter
+// and the Output of fiber's logger middleware.
+func (l *Logger) LevelWriter(level any) io.Writer {
+	return &levelWriter{l: l, level: castToZlogLevel(level)}
+}
+
+type levelWriter struct {
+	l     *Logger
+	level zerolog.Level
+}
+
+func (w *levelWriter) Write(p []byte) (n int, err error) {
+	c := w.l.config()
+	if e := c.withAutoCaller(c.event(w.level)); e != nil {
+		e.Msg(string(bytes.TrimRight(p, "\r\n")))
+	}
+	return len(p), nil
+}
@@ -0,0 +1,90 @@
+package zwrap
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"testing"
+
+	"github.com/rs/zerolog"
+)
+
+func TestEchoLevels(t *testing.T) {
+	el := NewEchoLogger(Wrap(zerolog.Nop()))
+	for _, level := range []EchoLvl{EchoDebug, EchoInfo, EchoWarn, EchoError, EchoOff} {
+		el.SetLevel(level)
+		if got := el.Level(); got != level {
+			t.Errorf("SetLevel(%d) then Level() = %d", level, got)
+		}
+	}
+}
+
+func TestEchoLogger(t *testing.T) {
+	buf := new(bytes.Buffer)
+	el := NewEchoLogger(Wrap(zerolog.New(new(bytes.Buffer))))
+	el.SetOutput(buf)
+	el.NoFatals(true)
+
+	el.Infoj(EchoJSON{"method": "GET", "status": 200})
+	el.Warnf("slow %s", "handler")
+	el.Fatalj(EchoJSON{"reason": "boom"})
+	fmt.Fprintln(el.Output(), "echo: http: TLS handshake error")
+	el.SetLevel(EchoError)
+	el.Debugj(EchoJSON{"dropped": true})
+
+	ms := decodeLines(t, buf)
+	if len(ms) != 4 {
+		t.Fatalf("expected 4 lines, got %d: %s", len(ms), buf.String())
+	}
+	if ms[0]["level"] != "info" || ms[0]["method"] != "GET" || ms[0]["status"] != float64(200) {
+		t.Errorf("unexpected record: %v", ms[0])
+	}
+	if ms[1]["level"] != "warn" || ms[1]["message"] != "slow handler" {
+		t.Errorf("unexpected record: %v", ms[1])
+	}
+	if ms[2]["level"] != "error" || ms[2]["message"] != "[FATAL BYPASSED]" || ms[2]["reason"] != "boom" {
+		t.Errorf("unexpected record: %v", ms[2])
+	}
+	if ms[3]["message"] != "echo: http: TLS handshake error" {
+		t.Errorf("unexpected record: %v", ms[3])
+	}
+}
+
+func TestLogger_LevelWriter(t *testing.T) {
+	buf := new(bytes.Buffer)
+	zl := Wrap(zerolog.New(buf).Level(zerolog.DebugLevel))
+
+	fmt.Fprintf(zl.LevelWriter(zerolog.DebugLevel), "[GIN-debug] GET /ping --> main.ping (3 handlers)\n")
+	fmt.Fprintf(zl.LevelWriter("error"), "[GIN] panic recovered\r\n")
+	fmt.Fprintf(zl.LevelWriter(zerolog.TraceLevel), "dropped\n")
+
+	ms := decodeLines(t, buf)
+	if len(ms) != 2 {
+		t.Fatalf("expected 2 lines, got %d: %s", len(ms), buf.String())
+	}
+	if ms[0]["level"] != "debug" || ms[0]["message"] != "[GIN-debug] GET /ping --> main.ping (3 handlers)" {
+		t.Errorf("unexpected record: %v", ms[0])
+	}
+	if ms[1]["level"] != "error" || ms[1]["message"] != "[GIN] panic recovered" {
+		t.Errorf("unexpected record: %v", ms[1])
+	}
+}
+
+type msgCaptureHook struct {
+	msgs []string
+}
+
+func (h *msgCaptureHook) Run(_ *zerolog.Event, _ zerolog.Level, msg string) {
+	h.msgs = append(h.msgs, msg)
+}
+
+func TestLevelWriter_MessageNotAliased(t *testing.T) {
+	hook := &msgCaptureHook{}
+	w := Wrap(zerolog.New(io.Discard).Hook(hook)).LevelWriter(zerolog.InfoLevel)
+	p := []byte("first\n")
+	_, _ = w.Write(p)
+	copy(p, "XXXXX")
+	if len(hook.msgs) != 1 || hook.msgs[0] != "first" {
+		t.Errorf("hook saw %q after the caller reused its buffer", hook.msgs)
+	}
+}
@@ -5,303 +5,326 @@ package zwrap
 import (
 	"bytes"
 	"fmt"
-	"strings"
 	"sync"
+	"sync/atomic"
 
 	"github.com/rs/zerolog"
 )
 
 type Logger struct {
+	// Logger is the underlying zerolog.Logger as of the Logger's creation. It is never replaced, so configuration
+	// changes are not reflected in it. The commonly used methods it promotes are shadowed by ones that use the
+	// current configuration, for anything else use ZLogger.
 	*zerolog.Logger
-	cachedZL *zerolog.Logger
-	mu       *sync.RWMutex
+
+	// cfg holds the current configuration, it is replaced as a whole whenever the configuration changes
+	// so that logging never has to take a lock.
+	cfg atomic.Pointer[config]
+	// mu serializes configuration changes.
+	mu sync.Mutex
+}
+
+// config is an immutable snapshot of a Logger's configuration.
+type config struct {
+	// zl is base with our hooks attached, all events are created from it.
+	zl   *zerolog.Logger
+	base zerolog.Logger
 
 	prefix     string
 	printLevel zerolog.Level
 	forceLevel *zerolog.Level
 	noPanic    bool
 	noFatal    bool
-}
+	verbosity  int
+	immutable  bool
+	keyValues  bool
 
-func (l *Logger) updateCachedZL() {
+	reportCaller bool
+	reportTrace  bool
+}
 
-	l.mu.RLock()
-	if l.cachedZL == nil {
-		l.mu.RUnlock()
-		return
-	}
-	l.mu.RUnlock()
+func (l *Logger) config() *config {
+	return l.cfg.Load()
+}
 
+// update publishes a copy of the current configuration modified by fn.
+func (l *Logger) update(fn func(c *config)) {
 	l.mu.Lock()
-	l.cachedZL = l.Logger
-	if l.forceLevel != nil {
-		ll := l.cachedZL.Level(*l.forceLevel)
-		l.cachedZL = &ll
-	}
-	if l.prefix != "" {
-		ll := l.cachedZL.With().Str("caller", l.prefix).Logger()
-		l.cachedZL = &ll
-	}
+	c := *l.config()
+	fn(&c)
+	c.hook()
+	l.cfg.Store(&c)
 	l.mu.Unlock()
 }
 
+// ZLogger returns the underlying zerolog.Logger, including the Logger's prefix and level.
 func (l *Logger) ZLogger() *zerolog.Logger {
-	l.mu.RLock()
-	if l.cachedZL != nil {
-		zl := l.cachedZL
-		l.mu.RUnlock()
-		return zl
-	}
-	l.mu.RUnlock()
-	l.cachedZL = &zerolog.Logger{}
-	l.updateCachedZL()
-	return l.cachedZL
+	return l.config().zl
 }
 
-func (l *Logger) Warning(args ...any) {
-	l.printLn(l.Logger.Warn(), false, args...)
+// GetLevel returns the current level of the underlying zerolog.Logger.
+func (l *Logger) GetLevel() zerolog.Level {
+	return l.config().zl.GetLevel()
 }
 
-func (l *Logger) Warningln(args ...any) {
-	l.printLn(l.Logger.Warn(), false, args...)
+// Level returns a copy of the underlying zerolog.Logger with its level set to lvl.
+func (l *Logger) Level(lvl zerolog.Level) zerolog.Logger {
+	return l.config().zl.Level(lvl)
 }
 
-func (l *Logger) V(level int) bool {
-	if level > 127 || level < 0 {
-		return false
-	}
-	if l.Logger.GetLevel() > zerolog.Level(int8(level)) {
-		return true
-	}
-	return false
+// With returns a zerolog.Context for a copy of the underlying zerolog.Logger.
+func (l *Logger) With() zerolog.Context {
+	return l.config().zl.With()
+}
+
+// UpdateContext adds the fields set by update to the Logger, as zerolog.Logger's UpdateContext does.
+func (l *Logger) UpdateContext(update func(c zerolog.Context) zerolog.Context) {
+	l.update(func(c *config) {
+		c.base = update(c.base.With()).Logger()
+	})
+}
+
+// WithLevel starts a new event at level, see zerolog.Logger.WithLevel.
+func (l *Logger) WithLevel(level zerolog.Level) *zerolog.Event {
+	return l.config().zl.WithLevel(level)
+}
+
+// Err starts a new event with err, see zerolog.Logger.Err.
+func (l *Logger) Err(err error) *zerolog.Event {
+	return l.config().zl.Err(err)
+}
+
+// Log starts a new event without a level, see zerolog.Logger.Log.
+func (l *Logger) Log() *zerolog.Event {
+	return l.config().zl.Log()
+}
+
+func (l *Logger) Warning(args ...any) {
+	c := l.config()
+	c.printLn(c.zl.Warn(), false, args...)
+}
+
+func (l *Logger) Warningln(args ...any) {
+	c := l.config()
+	c.printLn(c.zl.Warn(), false, args...)
 }
 
 func (l *Logger) SetPrefix(prefix string) {
-	l.mu.Lock()
-	l.prefix = prefix
-	l.mu.Unlock()
-	l.updateCachedZL()
+	l.update(func(c *config) {
+		c.prefix = prefix
+	})
 }
 
 func (l *Logger) SetPrintLevel(level zerolog.Level) {
-	l.mu.Lock()
-	l.printLevel = level
-	l.mu.Unlock()
-	l.updateCachedZL()
+	l.update(func(c *config) {
+		c.printLevel = level
+	})
 }
 
 func (l *Logger) Prefix() string {
-	l.mu.RLock()
-	p := l.myPrefix()
-	l.mu.RUnlock()
-	return p
-}
-
-func (l *Logger) myPrefix() string {
-	return l.prefix
+	return l.config().prefix
 }
 
 func (l *Logger) Println(v ...interface{}) {
-	l.mu.RLock()
-	l.printLn(l.Logger.WithLevel(l.printLevel), false, v...)
-	l.mu.RUnlock()
+	c := l.config()
+	c.printLn(c.zl.WithLevel(c.printLevel), false, v...)
 }
 
 func (l *Logger) Printf(format string, v ...interface{}) {
-	l.mu.RLock()
-	var str string
-	switch {
-	case len(v) == 0:
-		str = format
-	case len(v) == 1:
-		str = fmt.Sprintf(format, v[0])
-	default:
-		str = fmt.Sprintf(format, v...)
+	c := l.config()
+	if len(v) == 0 {
+		c.prepare(c.zl.WithLevel(c.printLevel), false).Msg(format)
+		return
 	}
-	l.printLn(l.Logger.WithLevel(l.printLevel), false, str)
-	l.mu.RUnlock()
+	c.printf(c.zl.WithLevel(c.printLevel), false, format, v...)
 }
 
 func (l *Logger) Print(v ...interface{}) {
-	l.mu.RLock()
-	l.printLn(l.Logger.WithLevel(l.printLevel), false, v...)
-	l.mu.RUnlock()
+	c := l.config()
+	c.printLn(c.zl.WithLevel(c.printLevel), false, v...)
 }
 
 func (l *Logger) Fatal(v ...interface{}) {
+	c := l.config()
 	var ok bool
-	if _, v, ok = l.checkFatalBypass("", v...); ok {
-		l.printLn(l.Logger.Fatal(), true, v...)
+	if _, v, ok = c.checkFatalBypass("", v...); ok {
+		c.printLn(c.zl.Fatal(), true, v...)
 		return
 	}
-	l.mu.RLock()
-	l.printLn(l.Logger.Error(), false, v...)
-	l.mu.RUnlock()
+	c.printLn(c.zl.Error(), false, v...)
 }
 
 func (l *Logger) Fatalf(format string, v ...interface{}) {
+	c := l.config()
 	var ok bool
-	if format, v, ok = l.checkFatalBypass(format, v...); ok {
-		l.printLn(l.Logger.Fatal(), true, fmt.Sprintf(format, v...))
+	if format, v, ok = c.checkFatalBypass(format, v...); ok {
+		c.printf(c.zl.Fatal(), true, format, v...)
 		return
 	}
-	l.mu.RLock()
-	l.printLn(l.Logger.Error(), false, fmt.Sprintf(format, v...))
-	l.mu.RUnlock()
+	c.printf(c.zl.Error(), false, format, v...)
 }
 
 func (l *Logger) Fatalln(v ...interface{}) {
+	c := l.config()
 	var ok bool
-	if _, v, ok = l.checkFatalBypass("", v...); ok {
-		l.printLn(l.Logger.Fatal(), true, v...)
+	if _, v, ok = c.checkFatalBypass("", v...); ok {
+		c.printLn(c.zl.Fatal(), true, v...)
 		return
 	}
-	l.mu.RLock()
-	l.printLn(l.Logger.Error(), false, v...)
-	l.mu.RUnlock()
+	c.printLn(c.zl.Error(), false, v...)
 }
 
 func (l *Logger) Panic(v ...interface{}) {
+	c := l.config()
 	var ok bool
-	if _, v, ok = l.checkPanicBypass("", v...); ok {
-		l.printLn(l.Logger.Panic(), true, v...)
+	if _, v, ok = c.checkPanicBypass("", v...); ok {
+		c.printLn(c.zl.Panic(), true, v...)
 		return
 	}
-	l.mu.RLock()
-	l.printLn(l.Logger.Error(), false, v...)
-	l.mu.RUnlock()
+	c.printLn(c.zl.Error(), false, v...)
 }
 
 func (l *Logger) Panicf(format string, v ...interface{}) {
+	c := l.config()
 	var ok bool
-	if format, v, ok = l.checkPanicBypass(format, v...); ok {
-		l.printLn(l.Logger.Panic(), true, fmt.Sprintf(format, v...))
+	if format, v, ok = c.checkPanicBypass(format, v...); ok {
+		c.printf(c.zl.Panic(), true, format, v...)
 		return
 	}
-	l.mu.RLock()
-	l.printLn(l.Logger.Error(), false, fmt.Sprintf(format, v...))
-	l.mu.RUnlock()
+	c.printf(c.zl.Error(), false, format, v...)
 }
 
 func (l *Logger) Panicln(v ...interface{}) {
+	c := l.config()
 	var ok bool
-	if _, v, ok = l.checkPanicBypass("", v...); ok {
-		l.printLn(l.Logger.Panic(), true, v...)
+	if _, v, ok = c.checkPanicBypass("", v...); ok {
+		c.printLn(c.zl.Panic(), true, v...)
 		return
 	}
-	l.mu.RLock()
-	l.printLn(l.Logger.Error(), false, v...)
-	l.mu.RUnlock()
+	c.printLn(c.zl.Error(), false, v...)
 }
 
 func (l *Logger) Errorf(format string, v ...interface{}) {
-	l.mu.RLock()
-	l.printLn(l.Logger.Error(), false, fmt.Sprintf(format, v...))
-	l.mu.RUnlock()
+	c := l.config()
+	c.printf(c.zl.Error(), false, format, v...)
 }
 
 func (l *Logger) Warnf(format string, v ...interface{}) {
-	l.mu.RLock()
-	l.printLn(l.Logger.Warn(), false, fmt.Sprintf(format, v...))
-	l.mu.RUnlock()
+	c := l.config()
+	c.printf(c.zl.Warn(), false, format, v...)
 }
 
 func (l *Logger) Infof(format string, v ...interface{}) {
-	l.mu.RLock()
-	l.printLn(l.Logger.Info(), false, fmt.Sprintf(format, v...))
-	l.mu.RUnlock()
+	c := l.config()
+	c.printf(c.zl.Info(), false, format, v...)
 }
 
 func (l *Logger) Debugf(format string, v ...interface{}) {
-	l.mu.RLock()
-	l.printLn(l.Logger.Debug(), false, fmt.Sprintf(format, v...))
-	l.mu.RUnlock()
+	c := l.config()
+	c.printf(c.zl.Debug(), false, format, v...)
 }
 
 func (l *Logger) Tracef(format string, v ...interface{}) {
-	l.mu.RLock()
-	l.printLn(l.Logger.Trace(), false, fmt.Sprintf(format, v...))
-	l.mu.RUnlock()
+	c := l.config()
+	c.printf(c.zl.Trace(), false, format, v...)
 }
 
 func (l *Logger) Error(v ...interface{}) {
-	l.mu.RLock()
-	l.printLn(l.Logger.Error(), false, v...)
-	l.mu.RUnlock()
+	c := l.config()
+	c.printLn(c.zl.Error(), false, v...)
 }
 
 func (l *Logger) Warn(v ...interface{}) {
-	l.mu.RLock()
-	l.printLn(l.Logger.Warn(), false, v...)
-	l.mu.RUnlock()
+	c := l.config()
+	c.printLn(c.zl.Warn(), false, v...)
 }
 
 func (l *Logger) Info(v ...interface{}) {
-	l.mu.RLock()
-	l.printLn(l.Logger.Info(), false, v...)
-	l.mu.RUnlock()
+	c := l.config()
+	c.printLn(c.zl.Info(), false, v...)
 }
 
 func (l *Logger) Debug(v ...interface{}) {
-	l.mu.RLock()
-	l.printLn(l.Logger.Debug(), false, v...)
-	l.mu.RUnlock()
+	c := l.config()
+	c.printLn(c.zl.Debug(), false, v...)
 }
 
 func (l *Logger) Trace(v ...interface{}) {
-	l.mu.RLock()
-	l.printLn(l.Logger.Trace(), false, v...)
-	l.mu.RUnlock()
+	c := l.config()
+	c.printLn(c.zl.Trace(), false, v...)
 }
 
 func (l *Logger) Errorln(v ...interface{}) {
-	l.mu.RLock()
-	l.printLn(l.Logger.Error(), false, v...)
-	l.mu.RUnlock()
+	c := l.config()
+	c.printLn(c.zl.Error(), false, v...)
 }
 
 func (l *Logger) Warnln(v ...interface{}) {
-	l.mu.RLock()
-	l.printLn(l.Logger.Warn(), false, v...)
-	l.mu.RUnlock()
+	c := l.config()
+	c.printLn(c.zl.Warn(), false, v...)
 }
 
 func (l *Logger) Infoln(v ...interface{}) {
-	l.mu.RLock()
-	l.printLn(l.Logger.Info(), false, v...)
-	l.mu.RUnlock()
+	c := l.config()
+	c.printLn(c.zl.Info(), false, v...)
 }
 
 func (l *Logger) Debugln(v ...interface{}) {
-	l.mu.RLock()
-	l.printLn(l.Logger.Debug(), false, v...)
-	l.mu.RUnlock()
+	c := l.config()
+	c.printLn(c.zl.Debug(), false, v...)
 }
 
 func (l *Logger) Traceln(v ...interface{}) {
-	l.mu.RLock()
-	l.printLn(l.Logger.Trace(), false, v...)
-	l.mu.RUnlock()
+	c := l.config()
+	c.printLn(c.zl.Trace(), false, v...)
+}
+
+// LevelAliasFieldName, if set, is the field Verbosef and Noticef record "verbose" or "notice" in, as zerolog has
+// no such levels and they log at trace and info level. Writers such as SyslogWriter use it to pick a finer
+// severity. It is empty by default, leaving the output of Verbosef and Noticef as is.
+var LevelAliasFieldName = ""
+
+// withLevelAlias records alias on e. It must be applied after prepare, which may replace e to apply the force level.
+func withLevelAlias(e *zerolog.Event, alias string) *zerolog.Event {
+	if e == nil || LevelAliasFieldName == "" {
+		return e
+	}
+	return e.Str(LevelAliasFieldName, alias)
 }
 
 func (l *Logger) Verbosef(format string, v ...interface{}) {
-	l.mu.RLock()
-	l.printLn(l.Logger.Trace(), false, fmt.Sprintf(format, v...))
-	l.mu.RUnlock()
+	c := l.config()
+	sendf(withLevelAlias(c.prepare(c.zl.Trace(), false), "verbose"), format, v...)
 }
 
 func (l *Logger) Noticef(format string, v ...interface{}) {
-	l.mu.RLock()
-	l.printLn(l.Logger.Info(), false, fmt.Sprintf(format, v...))
-	l.mu.RUnlock()
+	c := l.config()
+	sendf(withLevelAlias(c.prepare(c.zl.Info(), false), "notice"), format, v...)
 }
+
 func (l *Logger) Warningf(format string, v ...interface{}) {
-	l.mu.RLock()
-	l.printLn(l.Logger.Warn(), false, fmt.Sprintf(format, v...))
-	l.mu.RUnlock()
+	c := l.config()
+	c.printf(c.zl.Warn(), false, format, v...)
+}
+
+// Immutable controls whether the With* methods modify l and return it (the default),
+// or leave l untouched and return a modified Child instead.
+func (l *Logger) Immutable(b bool) {
+	l.update(func(c *config) {
+		c.immutable = b
+	})
+}
+
+// withTarget returns the Logger that a With* method should modify.
+func (l *Logger) withTarget() *Logger {
+	if l.config().immutable {
+		return l.Child()
+	}
+	return l
 }
 
 func (l *Logger) WithPrefix(prefix string) *Logger {
+	l = l.withTarget()
 	l.SetPrefix(prefix)
 	return l
 }
@@ -311,112 +334,186 @@ func (l *Logger) Logf(format string, v ...interface{}) {
 }
 
 func (l *Logger) WithFields(fields map[string]interface{}) *Logger {
-	l.mu.RLock()
-	nl := l.Logger.With().Fields(fields).Logger()
-	l.Logger = &nl
-	l.mu.RUnlock()
+	l = l.withTarget()
+	l.update(func(c *config) {
+		c.base = c.base.With().Fields(fields).Logger()
+	})
 	return l
 }
 
 // SetLevel is compatibility for ghettovoice/gosip/log.Logger
 func (l *Logger) SetLevel(level any) {
-	l.mu.Lock()
-	nl := l.Logger.Level(castToZlogLevel(level))
-	l.Logger = &nl
-	l.mu.Unlock()
-	l.updateCachedZL()
+	nl := castToZlogLevel(level)
+	l.update(func(c *config) {
+		c.base = c.base.Level(nl)
+	})
 }
 
 func (l *Logger) Write(p []byte) (n int, err error) {
-	l.mu.RLock()
-	l.Logger.WithLevel(l.printLevel).Msg(string(bytes.TrimSuffix(p, []byte("\n"))))
-	l.mu.RUnlock()
+	c := l.config()
+	c.withAutoCaller(c.zl.WithLevel(c.printLevel)).Msg(string(bytes.TrimSuffix(p, []byte("\n"))))
 	return len(p), nil
 }
 
+// Output is compatibility for log.Logger. A calldepth of 1 refers to the caller of Output, and the file:line
+// of that frame is recorded unless calldepth is 2 (the depth used by the log package's own helpers)
+// and caller reporting is off.
 func (l *Logger) Output(calldepth int, s string) error {
-	l.mu.RLock()
-	event := l.Logger.Info()
-	if calldepth != 2 {
-		if l.prefix != "" {
-			zerolog.CallerFieldName = "caller_file"
-		}
-		event.CallerSkipFrame(calldepth)
-		event = event.Caller()
+	c := l.config()
+	e := c.zl.Info()
+	if calldepth != 2 || c.reportCaller {
+		e = c.withCaller(e, calldepth)
 	}
-	event.Msg(s)
-	zerolog.CallerFieldName = "caller"
-	l.mu.RUnlock()
+	e.Msg(s)
 	return nil
 }
 
-func (l *Logger) transformZEvent(e *zerolog.Event) *zerolog.Event {
-	switch *l.forceLevel {
+func (c *config) transformZEvent(e *zerolog.Event) *zerolog.Event {
+	switch *c.forceLevel {
 	case zerolog.PanicLevel:
-		if !l.noPanic {
-			e = l.Logger.Panic()
+		if !c.noPanic {
+			e = c.zl.Panic()
 		}
 	case zerolog.FatalLevel:
-		if !l.noFatal {
-			e = l.Logger.Fatal()
+		if !c.noFatal {
+			e = c.zl.Fatal()
 		}
 	case zerolog.ErrorLevel:
-		e = l.Logger.Error()
+		e = c.zl.Error()
 	case zerolog.WarnLevel:
-		e = l.Logger.Warn()
+		e = c.zl.Warn()
 	case zerolog.InfoLevel:
-		e = l.Logger.Info()
+		e = c.zl.Info()
 	case zerolog.DebugLevel:
-		e = l.Logger.Debug()
+		e = c.zl.Debug()
 	case zerolog.TraceLevel:
-		e = l.Logger.Trace()
+		e = c.zl.Trace()
 	default:
-		panic(fmt.Sprintf("invalid logger config, bad force level %v", l.forceLevel))
+		panic(fmt.Sprintf("invalid logger config, bad force level %v", c.forceLevel))
 	}
 	return e
 }
 
-func (l *Logger) printLn(e *zerolog.Event, preserve bool, v ...interface{}) {
-	if l.forceLevel != nil && !preserve {
-		e = l.transformZEvent(e)
+// event starts an event at level, or at the force level if one is set. Unlike printLn it never panics or exits
+// unless a panic or fatal level is forced.
+func (c *config) event(level zerolog.Level) *zerolog.Event {
+	e := c.zl.WithLevel(level)
+	if c.forceLevel != nil {
+		e = c.transformZEvent(e)
 	}
-	if len(v) == 0 {
-		e.Msg("")
+	return e
+}
+
+// enabled reports whether an event started with event(level) would be written.
+func (c *config) enabled(level zerolog.Level) bool {
+	if c.forceLevel != nil {
+		switch {
+		case *c.forceLevel == zerolog.PanicLevel && c.noPanic:
+		case *c.forceLevel == zerolog.FatalLevel && c.noFatal:
+		default:
+			level = *c.forceLevel
+		}
+	}
+	return level >= c.zl.GetLevel() && level >= zerolog.GlobalLevel()
+}
+
+// prepare applies the force level, unless preserve is set, and caller reporting to e.
+func (c *config) prepare(e *zerolog.Event, preserve bool) *zerolog.Event {
+	if c.forceLevel != nil && !preserve {
+		e = c.transformZEvent(e)
+	}
+	return c.withAutoCaller(e)
+}
+
+func (c *config) printLn(e *zerolog.Event, preserve bool, v ...interface{}) {
+	if msg, ok := c.kvMessage(v); ok {
+		c.printw(e, preserve, msg, v[1:])
+		return
+	}
+	send(c.prepare(e, preserve), v...)
+}
+
+// printf is printLn for format strings, formatting only if the event is enabled.
+func (c *config) printf(e *zerolog.Event, preserve bool, format string, v ...interface{}) {
+	sendf(c.prepare(e, preserve), format, v...)
+}
+
+// sendf is send for format strings.
+func sendf(e *zerolog.Event, format string, v ...interface{}) {
+	if e == nil {
+		return
+	}
+	buf := msgBufs.Get().(*[]byte)
+	*buf = fmt.Appendf((*buf)[:0], format, v...)
+	e.Msg(string(*buf))
+	putMsgBuf(buf)
+}
+
+// send writes v separated by spaces as the message of e. The message is built in a pooled buffer and copied
+// into the string given to zerolog, as hooks may keep that string after Msg returns.
+func send(e *zerolog.Event, v ...interface{}) {
+	if e == nil {
 		return
 	}
-	if len(v) == 1 {
-		e.Msg(fmt.Sprint(v[0]))
+	switch len(v) {
+	case 0:
+		e.Msg("")
 		return
+	case 1:
+		if s, ok := v[0].(string); ok {
+			e.Msg(s)
+			return
+		}
 	}
-	strBuf := strBufs.Get().(*strings.Builder)
+	buf := msgBufs.Get().(*[]byte)
+	b := (*buf)[:0]
 	for i, val := range v {
 		if i > 0 {
-			strBuf.WriteString(" ")
+			b = append(b, ' ')
 		}
-		strBuf.WriteString(fmt.Sprint(val))
+		b = appendArg(b, val)
 	}
-	e.Msg(strBuf.String())
-	strBuf.Reset()
-	strBufs.Put(strBuf)
+	*buf = b
+	e.Msg(string(b))
+	putMsgBuf(buf)
+}
+
+// hook sets zl to base with our hooks attached.
+func (c *config) hook() {
+	hooked := c.base.Hook(prefixHook{c.prefix}, contextHook{})
+	if c.reportTrace {
+		hooked = hooked.Hook(traceHook{})
+	}
+	c.zl = &hooked
 }
 
 type prefixHook struct {
-	parent *Logger
+	prefix string
 }
 
 func (h prefixHook) Run(e *zerolog.Event, _ zerolog.Level, _ string) {
-	if h.parent.Prefix() != "" {
-		e.Str("caller", h.parent.myPrefix())
+	if h.prefix != "" {
+		e.Str("caller", h.prefix)
 	}
 }
 
+// Child returns a new Logger that writes to the same output with the same fields, prefix, print level,
+// force level, verbosity and bypass settings as l. Changes made to the child afterwards do not affect l,
+// and vice versa, so children can be handed to other packages safely.
+func (l *Logger) Child() *Logger {
+	c := *l.config()
+	nl := &Logger{Logger: c.zl}
+	nl.cfg.Store(&c)
+	return nl
+}
+
 func Wrap(l zerolog.Logger) *Logger {
-	wrapped := &Logger{
-		mu:         &sync.RWMutex{},
+	c := &config{
+		base:       l,
 		printLevel: zerolog.InfoLevel,
 	}
-	p := prefixHook{wrapped}
-	l = l.Hook(p)
-	wrapped.Logger = &l
+	c.hook()
+	wrapped := &Logger{Logger: c.zl}
+	wrapped.cfg.Store(c)
 	return wrapped
 }
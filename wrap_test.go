@@ -1,9 +1,12 @@
 package zwrap
 
 import (
+	"bytes"
 	"errors"
+	"io"
 	"log"
 	"os"
+	"strconv"
 	"strings"
 	"testing"
 
@@ -113,8 +116,8 @@ func TestWrap(t *testing.T) {
 
 	multiLog := func(wrapped *Logger, v ...interface{}) {
 		t.Helper()
-		toggleFatals := wrapped.noFatal == false
-		togglePanics := wrapped.noPanic == false
+		toggleFatals := wrapped.config().noFatal == false
+		togglePanics := wrapped.config().noPanic == false
 		wrapped.Print(v...)
 		wrapped.Printf("f: %v", v...)
 		wrapped.Println(v...)
@@ -158,8 +161,8 @@ func TestWrap(t *testing.T) {
 		}
 	}
 
-	if wzl.V(0) {
-		t.Error("V(0) should always return false")
+	if !wzl.V(0) || wzl.V(1) {
+		t.Error("V(0) should be true and V(1) false at the default verbosity")
 	}
 
 	t.Run("generic", func(t *testing.T) {
@@ -339,3 +342,86 @@ func ExampleWrap() {
 	myThing.SetLogger(zl)
 	myThing.DoSomething()
 }
+
+func TestLogger_Child(t *testing.T) {
+	buf := new(bytes.Buffer)
+	parent := Wrap(zerolog.New(buf))
+	parent.SetPrefix("parent")
+
+	child := parent.Child().WithPrefix("db").WithFields(map[string]interface{}{"table": "users"})
+	child.NoPanics(true)
+	child.Panic("yeet")
+	parent.Info("yeet")
+
+	if parent.Prefix() != "parent" || parent.config().noPanic {
+		t.Fatalf("child modified its parent")
+	}
+
+	parent.Immutable(true)
+	scoped := parent.WithPrefix("scoped").WithNoFatals()
+	if scoped == parent || parent.Prefix() != "parent" || parent.config().noFatal {
+		t.Fatalf("With* modified an immutable logger")
+	}
+	scoped.Info("yeet")
+
+	ms := decodeLines(t, buf)
+	if len(ms) != 3 {
+		t.Fatalf("expected 3 lines, got %d: %s", len(ms), buf.String())
+	}
+	if ms[0]["caller"] != "db" || ms[0]["table"] != "users" || ms[0]["level"] != "error" {
+		t.Errorf("unexpected child record: %v", ms[0])
+	}
+	if ms[1]["caller"] != "parent" || ms[1]["table"] != nil {
+		t.Errorf("unexpected parent record: %v", ms[1])
+	}
+	if ms[2]["caller"] != "scoped" {
+		t.Errorf("unexpected scoped record: %v", ms[2])
+	}
+}
+
+func TestLogger_ConcurrentReconfigure(t *testing.T) {
+	zl := Wrap(zerolog.New(io.Discard))
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 1000; i++ {
+			zl.SetPrefix(strconv.Itoa(i))
+			zl.SetLevel(i % 4)
+			zl.NoPanics(i%2 == 0)
+		}
+	}()
+	for i := 0; i < 1000; i++ {
+		zl.Info("yeet")
+		zl.Printf("f: %d", i)
+		_ = zl.Prefix()
+		_ = zl.ZLogger()
+		_ = zl.GetLevel()
+		_ = zl.With().Logger()
+		_ = zl.Level(zerolog.InfoLevel)
+		zl.WithLevel(zerolog.InfoLevel).Msg("yeet")
+	}
+	<-done
+}
+
+func TestLogger_UpdateContext(t *testing.T) {
+	buf := new(bytes.Buffer)
+	zl := Wrap(zerolog.New(buf))
+	zl.UpdateContext(func(c zerolog.Context) zerolog.Context {
+		return c.Str("k", "v")
+	})
+	zl.SetPrefix("after")
+	zl.SetLevel(zerolog.WarnLevel)
+	zl.Info("dropped")
+	zl.Warn("yeet")
+
+	if zl.GetLevel() != zerolog.WarnLevel {
+		t.Errorf("expected GetLevel to follow SetLevel, got %v", zl.GetLevel())
+	}
+	ms := decodeLines(t, buf)
+	if len(ms) != 1 {
+		t.Fatalf("expected 1 line, got %d: %s", len(ms), buf.String())
+	}
+	if ms[0]["k"] != "v" || ms[0]["caller"] != "after" {
+		t.Errorf("UpdateContext fields lost by a later configuration change: %v", ms[0])
+	}
+}
@@ -0,0 +1,105 @@
+package zwrap
+
+// ZapSugaredCompatLogger is an interface that provides compatibility with the logging methods of
+// go.uber.org/zap.SugaredLogger.
+type ZapSugaredCompatLogger interface {
+	Debug(args ...interface{})
+	Info(args ...interface{})
+	Warn(args ...interface{})
+	Error(args ...interface{})
+	DPanic(args ...interface{})
+	Panic(args ...interface{})
+	Fatal(args ...interface{})
+	Debugf(template string, args ...interface{})
+	Infof(template string, args ...interface{})
+	Warnf(template string, args ...interface{})
+	Errorf(template string, args ...interface{})
+	DPanicf(template string, args ...interface{})
+	Panicf(template string, args ...interface{})
+	Fatalf(template string, args ...interface{})
+	Debugw(msg string, keysAndValues ...interface{})
+	Infow(msg string, keysAndValues ...interface{})
+	Warnw(msg string, keysAndValues ...interface{})
+	Errorw(msg string, keysAndValues ...interface{})
+	DPanicw(msg string, keysAndValues ...interface{})
+	Panicw(msg string, keysAndValues ...interface{})
+	Fatalw(msg string, keysAndValues ...interface{})
+	Debugln(args ...interface{})
+	Infoln(args ...interface{})
+	Warnln(args ...interface{})
+	Errorln(args ...interface{})
+	DPanicln(args ...interface{})
+	Panicln(args ...interface{})
+	Fatalln(args ...interface{})
+	Sync() error
+}
+
+var _ ZapSugaredCompatLogger = (*SugaredLogger)(nil)
+
+// SugaredLogger adapts a Logger to the method set of zap's SugaredLogger. In development mode the DPanic
+// methods panic like Panic, unless panics are bypassed with NoPanics; otherwise they log at error level.
+type SugaredLogger struct {
+	*Logger
+	development bool
+}
+
+// NewSugaredLogger returns a SugaredLogger writing through l.
+func NewSugaredLogger(l *Logger, development bool) *SugaredLogger {
+	return &SugaredLogger{Logger: l, development: development}
+}
+
+// With returns a SugaredLogger with keysAndValues added as fields to a Child of the Logger.
+func (s *SugaredLogger) With(keysAndValues ...interface{}) *SugaredLogger {
+	nl := s.Logger.Child()
+	nl.update(func(c *config) {
+		c.base = c.base.With().Fields(normalizeKeyvals(keysAndValues)).Logger()
+	})
+	return &SugaredLogger{Logger: nl, development: s.development}
+}
+
+// Named returns a SugaredLogger whose prefix has name appended, separated by ".".
+func (s *SugaredLogger) Named(name string) *SugaredLogger {
+	nl := s.Logger.Child()
+	if prefix := nl.Prefix(); prefix != "" {
+		name = prefix + "." + name
+	}
+	nl.SetPrefix(name)
+	return &SugaredLogger{Logger: nl, development: s.development}
+}
+
+// Sync is a no-op, zerolog writes every event as it is logged.
+func (s *SugaredLogger) Sync() error {
+	return nil
+}
+
+func (s *SugaredLogger) DPanic(args ...interface{}) {
+	if s.development {
+		s.Logger.Panic(args...)
+		return
+	}
+	s.Logger.Error(args...)
+}
+
+func (s *SugaredLogger) DPanicf(template string, args ...interface{}) {
+	if s.development {
+		s.Logger.Panicf(template, args...)
+		return
+	}
+	s.Logger.Errorf(template, args...)
+}
+
+func (s *SugaredLogger) DPanicw(msg string, keysAndValues ...interface{}) {
+	if s.development {
+		s.Logger.Panicw(msg, keysAndValues...)
+		return
+	}
+	s.Logger.Errorw(msg, keysAndValues...)
+}
+
+func (s *SugaredLogger) DPanicln(args ...interface{}) {
+	if s.development {
+		s.Logger.Panicln(args...)
+		return
+	}
+	s.Logger.Errorln(args...)
+}
@@ -0,0 +1,49 @@
+package zwrap
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/rs/zerolog"
+)
+
+func TestSugaredLogger(t *testing.T) {
+	buf := new(bytes.Buffer)
+	zl := Wrap(zerolog.New(buf))
+
+	prod := NewSugaredLogger(zl, false).Named("svc").Named("db").With("conn", 2)
+	prod.DPanicw("not in production", "n", 1)
+	prod.Infow("queried", "rows", 3)
+	if err := prod.Sync(); err != nil {
+		t.Errorf("Sync() = %v", err)
+	}
+
+	dev := NewSugaredLogger(zl, true)
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Error("expected DPanic to panic in development mode")
+			}
+		}()
+		dev.DPanic("yeet")
+	}()
+	zl.NoPanics(true)
+	dev.DPanicf("bypassed %d", 1)
+
+	ms := decodeLines(t, buf)
+	if len(ms) != 4 {
+		t.Fatalf("expected 4 lines, got %d: %s", len(ms), buf.String())
+	}
+	if ms[0]["level"] != "error" || ms[0]["caller"] != "svc.db" || ms[0]["conn"] != float64(2) || ms[0]["n"] != float64(1) {
+		t.Errorf("unexpected record: %v", ms[0])
+	}
+	if ms[1]["rows"] != float64(3) || ms[1]["caller"] != "svc.db" {
+		t.Errorf("unexpected record: %v", ms[1])
+	}
+	if ms[2]["level"] != "panic" || ms[2]["caller"] != nil {
+		t.Errorf("unexpected record: %v", ms[2])
+	}
+	if ms[3]["level"] != "error" || ms[3]["message"] != "[PANIC BYPASSED] bypassed 1" {
+		t.Errorf("unexpected record: %v", ms[3])
+	}
+}